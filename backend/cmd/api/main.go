@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"time"
@@ -29,17 +30,46 @@ func main() {
 	farmerRepo := repository.NewFarmerRepository(db)
 	farmRepo := repository.NewFarmRepository(db)
 	harvestRepo := repository.NewHarvestRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	seasonRepo := repository.NewSeasonRepository(db)
+	cultureRepo := repository.NewCultureRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
 
 	// Inicializar serviços
-	farmerService := services.NewFarmerService(farmerRepo)
-	dashboardService := services.NewDashboardService(farmRepo, harvestRepo)
+	farmerService := services.NewFarmerService(farmerRepo, cultureRepo, unitOfWork)
+	farmService := services.NewFarmService(farmRepo, farmerRepo, cultureRepo, unitOfWork)
+	harvestService := services.NewHarvestService(harvestRepo, farmRepo, unitOfWork)
+	trashService := services.NewTrashService(trashRepo, unitOfWork)
+	auditService := services.NewAuditService(auditRepo)
+	searchService := services.NewSearchService(searchRepo)
+	seasonService := services.NewSeasonService(seasonRepo, unitOfWork)
+	cultureService := services.NewCultureService(cultureRepo, unitOfWork)
+	mapService := services.NewMapService(farmRepo, cultureRepo)
+	boundaryService := services.NewBoundaryService(farmRepo, farmService, mapService)
+	overlapService := services.NewOverlapService(farmRepo)
+	dashboardService := services.NewDashboardService(farmRepo, harvestRepo, cultureRepo)
+
+	// Limpar periodicamente a lixeira
+	go trashService.RunPurgeJob(context.Background(), cfg.TrashPurgeInterval, cfg.TrashRetention)
 
 	// Inicializar handlers com adaptadores
 	farmerHandler := handlers.NewFarmerHandler(handlers.NewFarmerServiceAdapter(farmerService))
+	farmHandler := handlers.NewFarmHandler(handlers.NewFarmServiceAdapter(farmService))
+	harvestHandler := handlers.NewHarvestHandler(handlers.NewHarvestServiceAdapter(harvestService))
+	trashHandler := handlers.NewTrashHandler(handlers.NewTrashServiceAdapter(trashService))
+	auditHandler := handlers.NewAuditHandler(handlers.NewAuditServiceAdapter(auditService))
+	searchHandler := handlers.NewSearchHandler(handlers.NewSearchServiceAdapter(searchService))
+	seasonHandler := handlers.NewSeasonHandler(handlers.NewSeasonServiceAdapter(seasonService))
+	cultureHandler := handlers.NewCultureHandler(handlers.NewCultureServiceAdapter(cultureService))
+	mapHandler := handlers.NewMapHandler(handlers.NewMapServiceAdapter(mapService))
+	boundaryHandler := handlers.NewBoundaryHandler(handlers.NewBoundaryServiceAdapter(boundaryService))
+	overlapHandler := handlers.NewOverlapHandler(handlers.NewOverlapServiceAdapter(overlapService))
 	dashboardHandler := handlers.NewDashboardHandler(handlers.NewDashboardServiceAdapter(dashboardService))
 
 	// Configurar rotas
-	router := routes.SetupRoutes(farmerHandler, dashboardHandler)
+	router := routes.SetupRoutes(farmerHandler, farmHandler, harvestHandler, trashHandler, auditHandler, searchHandler, seasonHandler, cultureHandler, mapHandler, boundaryHandler, overlapHandler, dashboardHandler)
 
 	// Configurar servidor HTTP
 	port := os.Getenv("PORT")
@@ -2,8 +2,11 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
 	"github.com/samuel-prates/farm-project/backend/internal/services"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
 )
 
 // ServiceAdapter adapts the real services to our interfaces for testing
@@ -31,18 +34,23 @@ func NewFarmerServiceAdapter(service *services.FarmerService) FarmerServiceInter
 }
 
 // Create implements FarmerServiceInterface
-func (a *FarmerServiceAdapter) Create(farmer *models.Farmer) (*models.Farmer, error) {
-	return a.service.Create(farmer)
+func (a *FarmerServiceAdapter) Create(ctx context.Context, farmer *models.Farmer) (*models.Farmer, error) {
+	return a.service.Create(ctx, farmer)
 }
 
 // Update implements FarmerServiceInterface
-func (a *FarmerServiceAdapter) Update(farmer *models.Farmer) (*models.Farmer, error) {
-	return a.service.Update(farmer)
+func (a *FarmerServiceAdapter) Update(ctx context.Context, farmer *models.Farmer, ifMatch string) (*models.Farmer, error) {
+	return a.service.Update(ctx, farmer, ifMatch)
+}
+
+// Patch implements FarmerServiceInterface
+func (a *FarmerServiceAdapter) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farmer, error) {
+	return a.service.Patch(ctx, id, patch, ifMatch)
 }
 
 // Delete implements FarmerServiceInterface
-func (a *FarmerServiceAdapter) Delete(id uint) error {
-	return a.service.Delete(id)
+func (a *FarmerServiceAdapter) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return a.service.Delete(ctx, id, ifMatch)
 }
 
 // GetByID implements FarmerServiceInterface
@@ -51,10 +59,290 @@ func (a *FarmerServiceAdapter) GetByID(id uint) (*models.Farmer, error) {
 }
 
 // GetAll implements FarmerServiceInterface
-func (a *FarmerServiceAdapter) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+func (a *FarmerServiceAdapter) GetAll(filter models.FarmerFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetAll(filter, params)
+}
+
+// GetPage implements FarmerServiceInterface
+func (a *FarmerServiceAdapter) GetPage(filter models.FarmerFilter, params models.CursorParams) (models.CursorResult, error) {
+	return a.service.GetPage(filter, params)
+}
+
+// FarmServiceAdapter adapts the real FarmService to our FarmServiceInterface
+type FarmServiceAdapter struct {
+	service *services.FarmService
+}
+
+// NewFarmServiceAdapter creates a new FarmServiceAdapter
+func NewFarmServiceAdapter(service *services.FarmService) FarmServiceInterface {
+	return &FarmServiceAdapter{service: service}
+}
+
+// Create implements FarmServiceInterface
+func (a *FarmServiceAdapter) Create(ctx context.Context, farm *models.Farm) (*models.Farm, error) {
+	return a.service.Create(ctx, farm)
+}
+
+// Update implements FarmServiceInterface
+func (a *FarmServiceAdapter) Update(ctx context.Context, farm *models.Farm, ifMatch string) (*models.Farm, error) {
+	return a.service.Update(ctx, farm, ifMatch)
+}
+
+// Patch implements FarmServiceInterface
+func (a *FarmServiceAdapter) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farm, error) {
+	return a.service.Patch(ctx, id, patch, ifMatch)
+}
+
+// Delete implements FarmServiceInterface
+func (a *FarmServiceAdapter) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return a.service.Delete(ctx, id, ifMatch)
+}
+
+// GetByID implements FarmServiceInterface
+func (a *FarmServiceAdapter) GetByID(id uint) (*models.Farm, error) {
+	return a.service.GetByID(id)
+}
+
+// GetAll implements FarmServiceInterface
+func (a *FarmServiceAdapter) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetAll(params)
+}
+
+// GetPage implements FarmServiceInterface
+func (a *FarmServiceAdapter) GetPage(params models.CursorParams) (models.CursorResult, error) {
+	return a.service.GetPage(params)
+}
+
+// GetAllByFarmer implements FarmServiceInterface
+func (a *FarmServiceAdapter) GetAllByFarmer(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetAllByFarmer(farmerID, params)
+}
+
+// GetNearby implements FarmServiceInterface
+func (a *FarmServiceAdapter) GetNearby(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetNearby(filter, params)
+}
+
+// HarvestServiceAdapter adapts the real HarvestService to our HarvestServiceInterface
+type HarvestServiceAdapter struct {
+	service *services.HarvestService
+}
+
+// NewHarvestServiceAdapter creates a new HarvestServiceAdapter
+func NewHarvestServiceAdapter(service *services.HarvestService) HarvestServiceInterface {
+	return &HarvestServiceAdapter{service: service}
+}
+
+// Create implements HarvestServiceInterface
+func (a *HarvestServiceAdapter) Create(ctx context.Context, harvest *models.Harvest) (*models.Harvest, error) {
+	return a.service.Create(ctx, harvest)
+}
+
+// Update implements HarvestServiceInterface
+func (a *HarvestServiceAdapter) Update(ctx context.Context, harvest *models.Harvest, ifMatch string) (*models.Harvest, error) {
+	return a.service.Update(ctx, harvest, ifMatch)
+}
+
+// Patch implements HarvestServiceInterface
+func (a *HarvestServiceAdapter) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Harvest, error) {
+	return a.service.Patch(ctx, id, patch, ifMatch)
+}
+
+// Delete implements HarvestServiceInterface
+func (a *HarvestServiceAdapter) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return a.service.Delete(ctx, id, ifMatch)
+}
+
+// GetByID implements HarvestServiceInterface
+func (a *HarvestServiceAdapter) GetByID(id uint) (*models.Harvest, error) {
+	return a.service.GetByID(id)
+}
+
+// GetAllByFarm implements HarvestServiceInterface
+func (a *HarvestServiceAdapter) GetAllByFarm(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetAllByFarm(farmID, params)
+}
+
+// SeasonServiceAdapter adapts the real SeasonService to our SeasonServiceInterface
+type SeasonServiceAdapter struct {
+	service *services.SeasonService
+}
+
+// NewSeasonServiceAdapter creates a new SeasonServiceAdapter
+func NewSeasonServiceAdapter(service *services.SeasonService) SeasonServiceInterface {
+	return &SeasonServiceAdapter{service: service}
+}
+
+// Create implements SeasonServiceInterface
+func (a *SeasonServiceAdapter) Create(ctx context.Context, season *models.Season) (*models.Season, error) {
+	return a.service.Create(ctx, season)
+}
+
+// Update implements SeasonServiceInterface
+func (a *SeasonServiceAdapter) Update(ctx context.Context, season *models.Season) (*models.Season, error) {
+	return a.service.Update(ctx, season)
+}
+
+// Delete implements SeasonServiceInterface
+func (a *SeasonServiceAdapter) Delete(id uint) error {
+	return a.service.Delete(id)
+}
+
+// GetByID implements SeasonServiceInterface
+func (a *SeasonServiceAdapter) GetByID(id uint) (*models.Season, error) {
+	return a.service.GetByID(id)
+}
+
+// GetAll implements SeasonServiceInterface
+func (a *SeasonServiceAdapter) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
 	return a.service.GetAll(params)
 }
 
+// CultureServiceAdapter adapts the real CultureService to our CultureServiceInterface
+type CultureServiceAdapter struct {
+	service *services.CultureService
+}
+
+// NewCultureServiceAdapter creates a new CultureServiceAdapter
+func NewCultureServiceAdapter(service *services.CultureService) CultureServiceInterface {
+	return &CultureServiceAdapter{service: service}
+}
+
+// Create implements CultureServiceInterface
+func (a *CultureServiceAdapter) Create(culture *models.Culture) (*models.Culture, error) {
+	return a.service.Create(culture)
+}
+
+// Update implements CultureServiceInterface
+func (a *CultureServiceAdapter) Update(culture *models.Culture) (*models.Culture, error) {
+	return a.service.Update(culture)
+}
+
+// Delete implements CultureServiceInterface
+func (a *CultureServiceAdapter) Delete(id uint) error {
+	return a.service.Delete(id)
+}
+
+// GetByID implements CultureServiceInterface
+func (a *CultureServiceAdapter) GetByID(id uint) (*models.Culture, error) {
+	return a.service.GetByID(id)
+}
+
+// GetAll implements CultureServiceInterface
+func (a *CultureServiceAdapter) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetAll(params)
+}
+
+// TrashServiceAdapter adapts the real TrashService to our TrashServiceInterface
+type TrashServiceAdapter struct {
+	service *services.TrashService
+}
+
+// NewTrashServiceAdapter creates a new TrashServiceAdapter
+func NewTrashServiceAdapter(service *services.TrashService) TrashServiceInterface {
+	return &TrashServiceAdapter{service: service}
+}
+
+// GetAll implements TrashServiceInterface
+func (a *TrashServiceAdapter) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetAll(params)
+}
+
+// RestoreFarmer implements TrashServiceInterface
+func (a *TrashServiceAdapter) RestoreFarmer(ctx context.Context, id uint) (*models.Farmer, error) {
+	return a.service.RestoreFarmer(ctx, id)
+}
+
+// AuditServiceAdapter adapts the real AuditService to our AuditServiceInterface
+type AuditServiceAdapter struct {
+	service *services.AuditService
+}
+
+// NewAuditServiceAdapter creates a new AuditServiceAdapter
+func NewAuditServiceAdapter(service *services.AuditService) AuditServiceInterface {
+	return &AuditServiceAdapter{service: service}
+}
+
+// GetHistory implements AuditServiceInterface
+func (a *AuditServiceAdapter) GetHistory(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return a.service.GetHistory(entityType, entityID, params)
+}
+
+// SearchServiceAdapter adapts the real SearchService to our SearchServiceInterface
+type SearchServiceAdapter struct {
+	service *services.SearchService
+}
+
+// NewSearchServiceAdapter creates a new SearchServiceAdapter
+func NewSearchServiceAdapter(service *services.SearchService) SearchServiceInterface {
+	return &SearchServiceAdapter{service: service}
+}
+
+// Search implements SearchServiceInterface
+func (a *SearchServiceAdapter) Search(query string, limit int) (models.SearchResult, error) {
+	return a.service.Search(query, limit)
+}
+
+// MapServiceAdapter adapts the real MapService to our MapServiceInterface
+type MapServiceAdapter struct {
+	service *services.MapService
+}
+
+// NewMapServiceAdapter creates a new MapServiceAdapter
+func NewMapServiceAdapter(service *services.MapService) MapServiceInterface {
+	return &MapServiceAdapter{service: service}
+}
+
+// GetFarms implements MapServiceInterface
+func (a *MapServiceAdapter) GetFarms(filter models.MapFilter) (*geo.FeatureCollection, error) {
+	return a.service.GetFarms(filter)
+}
+
+// BoundaryServiceAdapter adapts the real BoundaryService to our BoundaryServiceInterface
+type BoundaryServiceAdapter struct {
+	service *services.BoundaryService
+}
+
+// NewBoundaryServiceAdapter creates a new BoundaryServiceAdapter
+func NewBoundaryServiceAdapter(service *services.BoundaryService) BoundaryServiceInterface {
+	return &BoundaryServiceAdapter{service: service}
+}
+
+// Import implements BoundaryServiceInterface
+func (a *BoundaryServiceAdapter) Import(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+	return a.service.Import(ctx, farmID, data, ifMatch)
+}
+
+// ExportFarm implements BoundaryServiceInterface
+func (a *BoundaryServiceAdapter) ExportFarm(id uint, format string) (*models.BoundaryFile, error) {
+	return a.service.ExportFarm(id, format)
+}
+
+// Export implements BoundaryServiceInterface
+func (a *BoundaryServiceAdapter) Export(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+	return a.service.Export(filter, format)
+}
+
+// OverlapServiceAdapter adapts the real OverlapService to our OverlapServiceInterface
+type OverlapServiceAdapter struct {
+	service *services.OverlapService
+}
+
+// NewOverlapServiceAdapter creates a new OverlapServiceAdapter
+func NewOverlapServiceAdapter(service *services.OverlapService) OverlapServiceInterface {
+	return &OverlapServiceAdapter{service: service}
+}
+
+// GetByFarm implements OverlapServiceInterface
+func (a *OverlapServiceAdapter) GetByFarm(id uint) ([]models.FarmOverlap, error) {
+	return a.service.GetByFarm(id)
+}
+
+// Report implements OverlapServiceInterface
+func (a *OverlapServiceAdapter) Report() ([]models.FarmOverlapPair, error) {
+	return a.service.Report()
+}
+
 // DashboardServiceAdapter adapts the real DashboardService to our DashboardServiceInterface
 type DashboardServiceAdapter struct {
 	service *services.DashboardService
@@ -66,8 +354,8 @@ func NewDashboardServiceAdapter(service *services.DashboardService) DashboardSer
 }
 
 // GetDashboardData implements DashboardServiceInterface
-func (a *DashboardServiceAdapter) GetDashboardData() (*DashboardData, error) {
-	data, err := a.service.GetDashboardData()
+func (a *DashboardServiceAdapter) GetDashboardData(filter models.DashboardFilter) (*DashboardData, error) {
+	data, err := a.service.GetDashboardData(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -78,18 +366,18 @@ func (a *DashboardServiceAdapter) GetDashboardData() (*DashboardData, error) {
 }
 
 // GetFarmsByState implements DashboardServiceInterface
-func (a *DashboardServiceAdapter) GetFarmsByState() ([]models.StateCount, error) {
-	return a.service.GetFarmsByState()
+func (a *DashboardServiceAdapter) GetFarmsByState(filter models.DashboardFilter) ([]models.StateCount, error) {
+	return a.service.GetFarmsByState(filter)
 }
 
 // GetHarvestTypes implements DashboardServiceInterface
-func (a *DashboardServiceAdapter) GetHarvestTypes() ([]models.HarvestCultureCount, error) {
-	return a.service.GetHarvestTypes()
+func (a *DashboardServiceAdapter) GetHarvestTypes(filter models.DashboardFilter, byYear bool) ([]models.HarvestCultureCount, error) {
+	return a.service.GetHarvestTypes(filter, byYear)
 }
 
 // GetAreaDistribution implements DashboardServiceInterface
-func (a *DashboardServiceAdapter) GetAreaDistribution() (*AreaDistribution, error) {
-	data, err := a.service.GetAreaDistribution()
+func (a *DashboardServiceAdapter) GetAreaDistribution(filter models.DashboardFilter) (*AreaDistribution, error) {
+	data, err := a.service.GetAreaDistribution(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -98,3 +386,31 @@ func (a *DashboardServiceAdapter) GetAreaDistribution() (*AreaDistribution, erro
 		VegetationArea:  data.VegetationArea,
 	}, nil
 }
+
+// GetSummary implements DashboardServiceInterface
+func (a *DashboardServiceAdapter) GetSummary(ctx context.Context, filter models.DashboardFilter) (*DashboardSummary, error) {
+	data, err := a.service.GetSummary(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &DashboardSummary{
+		TotalFarms:      data.TotalFarms,
+		TotalArea:       data.TotalArea,
+		FarmsByState:    data.FarmsByState,
+		HarvestCultures: data.HarvestCultures,
+		AreaDistribution: AreaDistribution{
+			AgricultureArea: data.AreaDistribution.AgricultureArea,
+			VegetationArea:  data.AreaDistribution.VegetationArea,
+		},
+	}, nil
+}
+
+// GetProductionByCulture implements DashboardServiceInterface
+func (a *DashboardServiceAdapter) GetProductionByCulture(filter models.DashboardFilter) ([]models.CultureProduction, error) {
+	return a.service.GetProductionByCulture(filter)
+}
+
+// GetProductionBySeason implements DashboardServiceInterface
+func (a *DashboardServiceAdapter) GetProductionBySeason(filter models.DashboardFilter) ([]models.SeasonProduction, error) {
+	return a.service.GetProductionBySeason(filter)
+}
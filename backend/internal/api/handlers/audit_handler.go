@@ -0,0 +1,49 @@
+// internal/api/handlers/audit_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type AuditHandler struct {
+	service AuditServiceInterface
+}
+
+func NewAuditHandler(service AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+func (h *AuditHandler) GetFarmerHistory(w http.ResponseWriter, r *http.Request) {
+	h.getHistory(w, r, models.AuditEntityFarmer, "Erro ao buscar histórico do fazendeiro")
+}
+
+func (h *AuditHandler) GetFarmHistory(w http.ResponseWriter, r *http.Request) {
+	h.getHistory(w, r, models.AuditEntityFarm, "Erro ao buscar histórico da fazenda")
+}
+
+func (h *AuditHandler) getHistory(w http.ResponseWriter, r *http.Request, entityType, operation string) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao buscar histórico: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetHistory(entityType, uint(id), params)
+	if err != nil {
+		writeError(w, r, operation, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,172 @@
+// internal/api/handlers/audit_handler_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+)
+
+// MockAuditService is a mock implementation of the AuditServiceInterface
+type MockAuditService struct {
+	GetHistoryFunc func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+func (m *MockAuditService) GetHistory(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetHistoryFunc(entityType, entityID, params)
+}
+
+func TestAuditHandler_GetHistory(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name               string
+		path               string
+		id                 string
+		call               func(h *AuditHandler, w http.ResponseWriter, r *http.Request)
+		mockGetHistoryFunc func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error)
+		expectedStatus     int
+		expectedEntity     string
+	}{
+		{
+			name: "Farmer History",
+			path: "/api/farmers/1/history",
+			id:   "1",
+			call: (*AuditHandler).GetFarmerHistory,
+			mockGetHistoryFunc: func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				events := []models.AuditEvent{{ID: 1, EntityType: entityType, EntityID: entityID, Action: audit.ActionUpdate}}
+				return models.NewPaginatedResult(events, 1, params), nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedEntity: models.AuditEntityFarmer,
+		},
+		{
+			name: "Farm History",
+			path: "/api/farms/2/history",
+			id:   "2",
+			call: (*AuditHandler).GetFarmHistory,
+			mockGetHistoryFunc: func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				events := []models.AuditEvent{{ID: 2, EntityType: entityType, EntityID: entityID, Action: audit.ActionCreate}}
+				return models.NewPaginatedResult(events, 1, params), nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedEntity: models.AuditEntityFarm,
+		},
+		{
+			name: "Invalid ID",
+			path: "/api/farms/invalid/history",
+			id:   "invalid",
+			call: (*AuditHandler).GetFarmHistory,
+			mockGetHistoryFunc: func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service Error",
+			path: "/api/farmers/1/history",
+			id:   "1",
+			call: (*AuditHandler).GetFarmerHistory,
+			mockGetHistoryFunc: func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			var requestedEntity string
+			mockService := &MockAuditService{
+				GetHistoryFunc: func(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+					requestedEntity = entityType
+					return tt.mockGetHistoryFunc(entityType, entityID, params)
+				},
+			}
+			handler := NewAuditHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("GET", tt.path, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			tt.call(handler, rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			// For successful requests, check which history was requested
+			if tt.expectedStatus == http.StatusOK {
+				if requestedEntity != tt.expectedEntity {
+					t.Errorf("Handler requested history of %q, want %q", requestedEntity, tt.expectedEntity)
+				}
+
+				var result models.PaginatedResult
+				if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if result.Total != 1 {
+					t.Errorf("Handler returned wrong total: got %v want 1", result.Total)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		actor     string
+	}{
+		{name: "Headers Provided", requestID: "req-123", actor: "maria"},
+		{name: "Request ID Generated", requestID: "", actor: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var metadata audit.Metadata
+			handler := RequestContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				metadata = audit.FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("PUT", "/api/farms/1", nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			if tt.actor != "" {
+				req.Header.Set(ActorHeader, tt.actor)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if metadata.Actor != tt.actor {
+				t.Errorf("Actor = %q, want %q", metadata.Actor, tt.actor)
+			}
+			if metadata.RequestID == "" {
+				t.Error("Request ID should never be empty")
+			}
+			if tt.requestID != "" && metadata.RequestID != tt.requestID {
+				t.Errorf("Request ID = %q, want %q", metadata.RequestID, tt.requestID)
+			}
+			if got := rr.Header().Get(RequestIDHeader); got != metadata.RequestID {
+				t.Errorf("Response %s = %q, want %q", RequestIDHeader, got, metadata.RequestID)
+			}
+		})
+	}
+}
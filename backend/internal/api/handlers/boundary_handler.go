@@ -0,0 +1,132 @@
+// internal/api/handlers/boundary_handler.go
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+// maxBoundaryUploadSize caps the size of an uploaded boundary file
+const maxBoundaryUploadSize = 10 << 20
+
+// boundaryFileField is the multipart form field holding the uploaded file
+const boundaryFileField = "file"
+
+type BoundaryHandler struct {
+	service BoundaryServiceInterface
+}
+
+func NewBoundaryHandler(service BoundaryServiceInterface) *BoundaryHandler {
+	return &BoundaryHandler{service: service}
+}
+
+// Import replaces the farm's boundary with the polygons of a KML, KMZ or zipped
+// shapefile, sent either as the "file" field of a multipart form or as the raw body
+func (h *BoundaryHandler) Import(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao importar limite da fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	data, err := readBoundaryUpload(w, r)
+	if err != nil {
+		logger.Warn("Erro ao ler o arquivo de limite da fazenda: %v", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, "o arquivo deve ter no máximo 10 MB")
+			return
+		}
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao ler o arquivo: "+err.Error())
+		return
+	}
+
+	farm, err := h.service.Import(r.Context(), uint(id), data, r.Header.Get(IfMatchHeader))
+	if err != nil {
+		writeError(w, r, "Erro ao importar limite da fazenda", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, farm)
+}
+
+// ExportFarm downloads the farm's boundary in the format of the query string, KML by default
+func (h *BoundaryHandler) ExportFarm(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao exportar limite da fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	file, err := h.service.ExportFarm(uint(id), boundaryFormat(r))
+	if err != nil {
+		writeError(w, r, "Erro ao exportar limite da fazenda", err)
+		return
+	}
+
+	writeBoundaryFile(w, file)
+}
+
+// Export downloads the boundaries of the farms matching the map filters
+func (h *BoundaryHandler) Export(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseMapFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao exportar limites das fazendas: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	file, err := h.service.Export(filter, boundaryFormat(r))
+	if err != nil {
+		writeError(w, r, "Erro ao exportar limites das fazendas", err)
+		return
+	}
+
+	writeBoundaryFile(w, file)
+}
+
+func readBoundaryUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBoundaryUploadSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+
+	if err := r.ParseMultipartForm(maxBoundaryUploadSize); err != nil {
+		return nil, err
+	}
+	file, _, err := r.FormFile(boundaryFileField)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+func boundaryFormat(r *http.Request) string {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		return models.BoundaryFormatKML
+	}
+	return format
+}
+
+func writeBoundaryFile(w http.ResponseWriter, file *models.BoundaryFile) {
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+	w.Write(file.Data)
+}
@@ -0,0 +1,342 @@
+// internal/api/handlers/boundary_handler_test.go
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// MockBoundaryService is a mock implementation of the BoundaryServiceInterface
+type MockBoundaryService struct {
+	ImportFunc     func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error)
+	ExportFarmFunc func(id uint, format string) (*models.BoundaryFile, error)
+	ExportFunc     func(filter models.MapFilter, format string) (*models.BoundaryFile, error)
+}
+
+func (m *MockBoundaryService) Import(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+	return m.ImportFunc(ctx, farmID, data, ifMatch)
+}
+
+func (m *MockBoundaryService) ExportFarm(id uint, format string) (*models.BoundaryFile, error) {
+	return m.ExportFarmFunc(id, format)
+}
+
+func (m *MockBoundaryService) Export(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+	return m.ExportFunc(filter, format)
+}
+
+const testKML = `<kml><Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs></Polygon></kml>`
+
+func multipartUpload(t *testing.T, field, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "AREA_IMOVEL.kml")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write([]byte(content))
+	writer.Close()
+	return &body, writer.FormDataContentType()
+}
+
+func TestBoundaryHandler_Import(t *testing.T) {
+	tests := []struct {
+		name           string
+		farmID         string
+		body           func(t *testing.T) (*bytes.Buffer, string)
+		mockImportFunc func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error)
+		expectedStatus int
+	}{
+		{
+			name:   "Multipart Upload",
+			farmID: "1",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return multipartUpload(t, "file", testKML)
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				farm := validFarm()
+				farm.ID = farmID
+				return &farm, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Raw Body",
+			farmID: "1",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return bytes.NewBufferString(testKML), "application/vnd.google-earth.kml+xml"
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				farm := validFarm()
+				farm.ID = farmID
+				return &farm, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Missing File Field",
+			farmID: "1",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return multipartUpload(t, "upload", testKML)
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "File Too Large",
+			farmID: "1",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return bytes.NewBuffer(make([]byte, maxBoundaryUploadSize+1)), "application/octet-stream"
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "Invalid File",
+			farmID: "1",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return bytes.NewBufferString("not a boundary"), "text/plain"
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				return nil, validation.Errors{{Field: "file", Code: validation.CodeInvalid, Message: "arquivo KML inválido"}}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "Stale Version",
+			farmID: "1",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return bytes.NewBufferString(testKML), "application/vnd.google-earth.kml+xml"
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				return nil, apperrors.ErrPreconditionFailed
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "Invalid ID",
+			farmID: "abc",
+			body: func(t *testing.T) (*bytes.Buffer, string) {
+				return bytes.NewBufferString(testKML), "application/vnd.google-earth.kml+xml"
+			},
+			mockImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotData []byte
+			var gotIfMatch string
+			mockService := &MockBoundaryService{
+				ImportFunc: func(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+					gotData, gotIfMatch = data, ifMatch
+					return tt.mockImportFunc(ctx, farmID, data, ifMatch)
+				},
+			}
+			handler := NewBoundaryHandler(mockService)
+
+			body, contentType := tt.body(t)
+			req, err := http.NewRequest("PUT", "/api/farms/"+tt.farmID+"/boundary", body)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set(IfMatchHeader, `"farm-1-v1"`)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmID})
+
+			rr := httptest.NewRecorder()
+			handler.Import(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v: %s", status, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if string(gotData) != testKML {
+				t.Errorf("Service received %q, want the uploaded file", gotData)
+			}
+			if gotIfMatch != `"farm-1-v1"` {
+				t.Errorf("Service received If-Match %q", gotIfMatch)
+			}
+			if rr.Header().Get(ETagHeader) == "" {
+				t.Errorf("Handler did not set the ETag header")
+			}
+		})
+	}
+}
+
+func TestBoundaryHandler_ExportFarm(t *testing.T) {
+	tests := []struct {
+		name               string
+		farmID             string
+		query              string
+		mockExportFarmFunc func(id uint, format string) (*models.BoundaryFile, error)
+		expectedStatus     int
+		expectedFormat     string
+	}{
+		{
+			name:   "Default Format",
+			farmID: "1",
+			mockExportFarmFunc: func(id uint, format string) (*models.BoundaryFile, error) {
+				return &models.BoundaryFile{Name: "fazenda-1.kml", ContentType: "application/vnd.google-earth.kml+xml", Data: []byte(testKML)}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedFormat: models.BoundaryFormatKML,
+		},
+		{
+			name:   "Shapefile",
+			farmID: "1",
+			query:  "?format=SHP",
+			mockExportFarmFunc: func(id uint, format string) (*models.BoundaryFile, error) {
+				return &models.BoundaryFile{Name: "fazenda-1.zip", ContentType: "application/zip", Data: []byte("PK")}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedFormat: models.BoundaryFormatShapefile,
+		},
+		{
+			name:   "Invalid Format",
+			farmID: "1",
+			query:  "?format=dwg",
+			mockExportFarmFunc: func(id uint, format string) (*models.BoundaryFile, error) {
+				return nil, validation.Errors{{Field: "format", Code: validation.CodeInvalid, Message: "format deve ser kml, kmz ou shp"}}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "No Boundary",
+			farmID: "1",
+			mockExportFarmFunc: func(id uint, format string) (*models.BoundaryFile, error) {
+				return nil, apperrors.NotFound("a fazenda não possui limite cadastrado")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Invalid ID",
+			farmID: "abc",
+			mockExportFarmFunc: func(id uint, format string) (*models.BoundaryFile, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFormat string
+			mockService := &MockBoundaryService{
+				ExportFarmFunc: func(id uint, format string) (*models.BoundaryFile, error) {
+					gotFormat = format
+					return tt.mockExportFarmFunc(id, format)
+				},
+			}
+			handler := NewBoundaryHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/farms/"+tt.farmID+"/boundary"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmID})
+
+			rr := httptest.NewRecorder()
+			handler.ExportFarm(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if gotFormat != tt.expectedFormat {
+				t.Errorf("Service called with format %q, want %q", gotFormat, tt.expectedFormat)
+			}
+			if disposition := rr.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment") || !strings.Contains(disposition, "fazenda-1") {
+				t.Errorf("Unexpected Content-Disposition %q", disposition)
+			}
+			if rr.Header().Get("Content-Type") == "" || rr.Body.Len() == 0 {
+				t.Errorf("Handler did not write the file")
+			}
+		})
+	}
+}
+
+func TestBoundaryHandler_Export(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mockExportFunc func(filter models.MapFilter, format string) (*models.BoundaryFile, error)
+		expectedStatus int
+	}{
+		{
+			name:  "Success",
+			query: "?format=kmz&state=MT",
+			mockExportFunc: func(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+				if filter.State != "MT" || format != models.BoundaryFormatKMZ {
+					return nil, errors.New("unexpected arguments")
+				}
+				return &models.BoundaryFile{Name: "fazendas.kmz", ContentType: "application/vnd.google-earth.kmz", Data: []byte("PK")}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Invalid Filter",
+			query: "?bbox=1,2",
+			mockExportFunc: func(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Service Error",
+			query: "",
+			mockExportFunc: func(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockBoundaryService{ExportFunc: tt.mockExportFunc}
+			handler := NewBoundaryHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/map/farms/export"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.Export(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus == http.StatusOK && rr.Header().Get("Content-Type") != "application/vnd.google-earth.kmz" {
+				t.Errorf("Unexpected Content-Type %q", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// internal/api/handlers/culture_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type CultureHandler struct {
+	service CultureServiceInterface
+}
+
+func NewCultureHandler(service CultureServiceInterface) *CultureHandler {
+	return &CultureHandler{service: service}
+}
+
+func (h *CultureHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var culture models.Culture
+	if err := json.NewDecoder(r.Body).Decode(&culture); err != nil {
+		logger.Warn("Erro ao decodificar JSON: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	if err := culture.Validate(); err != nil {
+		logger.Warn("Erro de validação ao criar cultura: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	createdCulture, err := h.service.Create(&culture)
+	if err != nil {
+		writeError(w, r, "Erro ao criar cultura", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdCulture)
+}
+
+func (h *CultureHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar cultura: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var culture models.Culture
+	if err := json.NewDecoder(r.Body).Decode(&culture); err != nil {
+		logger.Warn("Erro ao decodificar JSON na atualização de cultura: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	culture.ID = uint(id)
+	if err := culture.Validate(); err != nil {
+		logger.Warn("Erro de validação ao atualizar cultura: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	updatedCulture, err := h.service.Update(&culture)
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar cultura", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updatedCulture)
+}
+
+func (h *CultureHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao excluir cultura: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		writeError(w, r, "Erro ao excluir cultura", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CultureHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao buscar cultura: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	culture, err := h.service.GetByID(uint(id))
+	if err != nil {
+		writeError(w, r, "Erro ao buscar cultura", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(culture)
+}
+
+func (h *CultureHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetAll(params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar culturas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,401 @@
+// internal/api/handlers/culture_handler_test.go
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+)
+
+// MockCultureService is a mock implementation of the CultureServiceInterface
+type MockCultureService struct {
+	CreateFunc  func(culture *models.Culture) (*models.Culture, error)
+	UpdateFunc  func(culture *models.Culture) (*models.Culture, error)
+	DeleteFunc  func(id uint) error
+	GetByIDFunc func(id uint) (*models.Culture, error)
+	GetAllFunc  func(params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+func (m *MockCultureService) Create(culture *models.Culture) (*models.Culture, error) {
+	return m.CreateFunc(culture)
+}
+
+func (m *MockCultureService) Update(culture *models.Culture) (*models.Culture, error) {
+	return m.UpdateFunc(culture)
+}
+
+func (m *MockCultureService) Delete(id uint) error {
+	return m.DeleteFunc(id)
+}
+
+func (m *MockCultureService) GetByID(id uint) (*models.Culture, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockCultureService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetAllFunc(params)
+}
+
+func validCultureBody() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "Soja",
+		"category":    models.CategoryGrao,
+		"defaultUnit": models.UnitSacas,
+		"aliases":     []string{"Soybean", "Soy"},
+	}
+}
+
+func TestCultureHandler_Create(t *testing.T) {
+	invalidCategory := validCultureBody()
+	invalidCategory["category"] = "legume"
+
+	invalidUnit := validCultureBody()
+	invalidUnit["defaultUnit"] = "litros"
+
+	emptyAlias := validCultureBody()
+	emptyAlias["aliases"] = []string{" "}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockCreateFunc func(culture *models.Culture) (*models.Culture, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			requestBody: validCultureBody(),
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				culture.ID = 1
+				return culture, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "Invalid JSON",
+			requestBody: "invalid json",
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid Category",
+			requestBody: invalidCategory,
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Invalid Default Unit",
+			requestBody: invalidUnit,
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Empty Alias",
+			requestBody: emptyAlias,
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Name Taken",
+			requestBody: validCultureBody(),
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, apperrors.Conflict("o nome \"Soy\" já pertence à cultura \"Soja\"")
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:        "Service Error",
+			requestBody: validCultureBody(),
+			mockCreateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCultureService{
+				CreateFunc: tt.mockCreateFunc,
+			}
+			handler := NewCultureHandler(mockService)
+
+			var reqBody []byte
+			var err error
+			if str, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, err = json.Marshal(tt.requestBody)
+				if err != nil {
+					t.Fatalf("Failed to marshal request body: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest("POST", "/api/cultures", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.Create(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			if tt.expectedStatus == http.StatusCreated {
+				var response struct {
+					Name    string   `json:"name"`
+					Aliases []string `json:"aliases"`
+				}
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+				if response.Name != "Soja" || !reflect.DeepEqual(response.Aliases, []string{"Soybean", "Soy"}) {
+					t.Errorf("Handler returned unexpected body: %+v", response)
+				}
+			}
+		})
+	}
+}
+
+func TestCultureHandler_Update(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		cultureID      string
+		mockUpdateFunc func(culture *models.Culture) (*models.Culture, error)
+		expectedStatus int
+	}{
+		{
+			name:      "Success",
+			cultureID: "1",
+			mockUpdateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				if culture.ID != 1 {
+					return nil, errors.New("id not assigned")
+				}
+				return culture, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "Invalid ID",
+			cultureID: "invalid",
+			mockUpdateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Not Found",
+			cultureID: "999",
+			mockUpdateFunc: func(culture *models.Culture) (*models.Culture, error) {
+				return nil, apperrors.NotFound("cultura não encontrada")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCultureService{
+				UpdateFunc: tt.mockUpdateFunc,
+			}
+			handler := NewCultureHandler(mockService)
+
+			reqBody, err := json.Marshal(validCultureBody())
+			if err != nil {
+				t.Fatalf("Failed to marshal request body: %v", err)
+			}
+
+			req, err := http.NewRequest("PUT", "/api/cultures/"+tt.cultureID, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.cultureID})
+
+			rr := httptest.NewRecorder()
+			handler.Update(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestCultureHandler_Delete(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		cultureID      string
+		mockDeleteFunc func(id uint) error
+		expectedStatus int
+	}{
+		{
+			name:      "Success",
+			cultureID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "Invalid ID",
+			cultureID: "invalid",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Still Referenced",
+			cultureID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return apperrors.Conflict("registro referenciado não existe ou ainda está em uso")
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCultureService{
+				DeleteFunc: tt.mockDeleteFunc,
+			}
+			handler := NewCultureHandler(mockService)
+
+			req, err := http.NewRequest("DELETE", "/api/cultures/"+tt.cultureID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.cultureID})
+
+			rr := httptest.NewRecorder()
+			handler.Delete(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestCultureHandler_GetByID(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		cultureID       string
+		mockGetByIDFunc func(id uint) (*models.Culture, error)
+		expectedStatus  int
+	}{
+		{
+			name:      "Success",
+			cultureID: "1",
+			mockGetByIDFunc: func(id uint) (*models.Culture, error) {
+				return &models.Culture{ID: id, Name: "Soja", Category: models.CategoryGrao}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "Invalid ID",
+			cultureID: "invalid",
+			mockGetByIDFunc: func(id uint) (*models.Culture, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Not Found",
+			cultureID: "999",
+			mockGetByIDFunc: func(id uint) (*models.Culture, error) {
+				return nil, apperrors.NotFound("cultura não encontrada")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCultureService{
+				GetByIDFunc: tt.mockGetByIDFunc,
+			}
+			handler := NewCultureHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/cultures/"+tt.cultureID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.cultureID})
+
+			rr := httptest.NewRecorder()
+			handler.GetByID(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestCultureHandler_GetAll(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockGetAllFunc func(params models.PaginationParams) (models.PaginatedResult, error)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				cultures := []models.Culture{{ID: 1, Name: "Soja", Category: models.CategoryGrao}}
+				return models.NewPaginatedResult(cultures, 1, params), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Service Error",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockCultureService{
+				GetAllFunc: tt.mockGetAllFunc,
+			}
+			handler := NewCultureHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/cultures?page=1&limit=10", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetAll(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
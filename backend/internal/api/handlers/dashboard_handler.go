@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
 )
 
 type DashboardHandler struct {
@@ -17,10 +18,16 @@ func NewDashboardHandler(service DashboardServiceInterface) *DashboardHandler {
 }
 
 func (h *DashboardHandler) GetDashboardData(w http.ResponseWriter, r *http.Request) {
-	data, err := h.service.GetDashboardData()
+	filter, err := parseDashboardFilter(r)
 	if err != nil {
-		logger.Error("Erro ao buscar dados do dashboard: %v", err)
-		http.Error(w, "Erro ao buscar dados do dashboard: "+err.Error(), http.StatusInternalServerError)
+		logger.Warn("Filtros inválidos ao buscar dados do dashboard: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetDashboardData(filter)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar dados do dashboard", err)
 		return
 	}
 
@@ -29,10 +36,16 @@ func (h *DashboardHandler) GetDashboardData(w http.ResponseWriter, r *http.Reque
 }
 
 func (h *DashboardHandler) GetFarmsByState(w http.ResponseWriter, r *http.Request) {
-	data, err := h.service.GetFarmsByState()
+	filter, err := parseDashboardFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar fazendas por estado: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetFarmsByState(filter)
 	if err != nil {
-		logger.Error("Erro ao buscar fazendas por estado: %v", err)
-		http.Error(w, "Erro ao buscar fazendas por estado: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao buscar fazendas por estado", err)
 		return
 	}
 
@@ -41,10 +54,24 @@ func (h *DashboardHandler) GetFarmsByState(w http.ResponseWriter, r *http.Reques
 }
 
 func (h *DashboardHandler) GetHarvestTypes(w http.ResponseWriter, r *http.Request) {
-	data, err := h.service.GetHarvestTypes()
+	filter, err := parseDashboardFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar tipos de cultivo: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	var errs validation.Errors
+	byYear := parseBoolParam(r.URL.Query(), "byYear", &errs)
+	if err := errs.Err(); err != nil {
+		logger.Warn("Agrupamento inválido ao buscar tipos de cultivo: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetHarvestTypes(filter, byYear)
 	if err != nil {
-		logger.Error("Erro ao buscar tipos de cultivo: %v", err)
-		http.Error(w, "Erro ao buscar tipos de cultivo: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao buscar tipos de cultivo", err)
 		return
 	}
 
@@ -53,10 +80,70 @@ func (h *DashboardHandler) GetHarvestTypes(w http.ResponseWriter, r *http.Reques
 }
 
 func (h *DashboardHandler) GetAreaDistribution(w http.ResponseWriter, r *http.Request) {
-	data, err := h.service.GetAreaDistribution()
+	filter, err := parseDashboardFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar distribuição de áreas: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetAreaDistribution(filter)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar distribuição de áreas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *DashboardHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDashboardFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar resumo do dashboard: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetSummary(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar resumo do dashboard", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *DashboardHandler) GetProductionByCulture(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDashboardFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar produção por cultura: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetProductionByCulture(filter)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar produção por cultura", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *DashboardHandler) GetProductionBySeason(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseDashboardFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar produção por temporada: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	data, err := h.service.GetProductionBySeason(filter)
 	if err != nil {
-		logger.Error("Erro ao buscar distribuição de áreas: %v", err)
-		http.Error(w, "Erro ao buscar distribuição de áreas: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao buscar produção por temporada", err)
 		return
 	}
 
@@ -2,6 +2,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -17,24 +18,43 @@ type MockDashboardService struct {
 	GetFarmsByStateFunc     func() ([]models.StateCount, error)
 	GetHarvestTypesFunc     func() ([]models.HarvestCultureCount, error)
 	GetAreaDistributionFunc func() (*AreaDistribution, error)
+	GetSummaryFunc          func() (*DashboardSummary, error)
+	GetProductionFunc       func() ([]models.CultureProduction, error)
+	GetSeasonProductionFunc func() ([]models.SeasonProduction, error)
+
+	// ByYear records the breakdown GetHarvestTypes was last called with
+	ByYear bool
 }
 
-func (m *MockDashboardService) GetDashboardData() (*DashboardData, error) {
+func (m *MockDashboardService) GetDashboardData(filter models.DashboardFilter) (*DashboardData, error) {
 	return m.GetDashboardDataFunc()
 }
 
-func (m *MockDashboardService) GetFarmsByState() ([]models.StateCount, error) {
+func (m *MockDashboardService) GetFarmsByState(filter models.DashboardFilter) ([]models.StateCount, error) {
 	return m.GetFarmsByStateFunc()
 }
 
-func (m *MockDashboardService) GetHarvestTypes() ([]models.HarvestCultureCount, error) {
+func (m *MockDashboardService) GetHarvestTypes(filter models.DashboardFilter, byYear bool) ([]models.HarvestCultureCount, error) {
+	m.ByYear = byYear
 	return m.GetHarvestTypesFunc()
 }
 
-func (m *MockDashboardService) GetAreaDistribution() (*AreaDistribution, error) {
+func (m *MockDashboardService) GetAreaDistribution(filter models.DashboardFilter) (*AreaDistribution, error) {
 	return m.GetAreaDistributionFunc()
 }
 
+func (m *MockDashboardService) GetSummary(ctx context.Context, filter models.DashboardFilter) (*DashboardSummary, error) {
+	return m.GetSummaryFunc()
+}
+
+func (m *MockDashboardService) GetProductionByCulture(filter models.DashboardFilter) ([]models.CultureProduction, error) {
+	return m.GetProductionFunc()
+}
+
+func (m *MockDashboardService) GetProductionBySeason(filter models.DashboardFilter) ([]models.SeasonProduction, error) {
+	return m.GetSeasonProductionFunc()
+}
+
 func TestDashboardHandler_GetDashboardData(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -86,6 +106,13 @@ func TestDashboardHandler_GetDashboardData(t *testing.T) {
 				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
 			}
 
+			// Errors are reported as problem documents
+			if tt.expectedStatus != http.StatusOK {
+				if contentType := rr.Header().Get("Content-Type"); contentType != "application/problem+json" {
+					t.Errorf("Handler returned wrong content type: got %q", contentType)
+				}
+			}
+
 			// For successful responses, verify the response body
 			if tt.expectedStatus == http.StatusOK {
 				var response DashboardData
@@ -224,6 +251,75 @@ func TestDashboardHandler_GetHarvestTypes(t *testing.T) {
 	}
 }
 
+func TestDashboardHandler_GetHarvestTypes_ByYear(t *testing.T) {
+	year := 2024
+
+	// Test cases
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedByYear bool
+	}{
+		{
+			name:           "Totals",
+			query:          "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "By Year",
+			query:          "?byYear=true",
+			expectedStatus: http.StatusOK,
+			expectedByYear: true,
+		},
+		{
+			name:           "Invalid Breakdown",
+			query:          "?byYear=sometimes",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockDashboardService{
+				GetHarvestTypesFunc: func() ([]models.HarvestCultureCount, error) {
+					return []models.HarvestCultureCount{
+						{Culture: "Soja", Year: &year, Count: 3, Farms: 2, PlantedArea: 150},
+					}, nil
+				},
+			}
+			handler := NewDashboardHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/dashboard/harvest-cultures"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetHarvestTypes(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if mockService.ByYear != tt.expectedByYear {
+				t.Errorf("Service called with byYear %v, want %v", mockService.ByYear, tt.expectedByYear)
+			}
+
+			var response []map[string]interface{}
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response body: %v", err)
+			}
+			if len(response) != 1 || response[0]["year"] != float64(2024) || response[0]["farms"] != float64(2) || response[0]["plantedArea"] != float64(150) {
+				t.Errorf("Handler returned unexpected body: %+v", response)
+			}
+		})
+	}
+}
+
 func TestDashboardHandler_GetAreaDistribution(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -290,3 +386,221 @@ func TestDashboardHandler_GetAreaDistribution(t *testing.T) {
 		})
 	}
 }
+
+func TestDashboardHandler_GetSummary(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name               string
+		mockGetSummaryFunc func() (*DashboardSummary, error)
+		expectedStatus     int
+	}{
+		{
+			name: "Success",
+			mockGetSummaryFunc: func() (*DashboardSummary, error) {
+				return &DashboardSummary{
+					TotalFarms:       3,
+					TotalArea:        160,
+					FarmsByState:     []models.StateCount{{State: "MT", Count: 2}, {State: "SP", Count: 1}},
+					HarvestCultures:  []models.HarvestCultureCount{{Culture: "Soja", Count: 2}},
+					AreaDistribution: AreaDistribution{AgricultureArea: 85, VegetationArea: 75},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Service Error",
+			mockGetSummaryFunc: func() (*DashboardSummary, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockDashboardService{
+				GetSummaryFunc: tt.mockGetSummaryFunc,
+			}
+			handler := NewDashboardHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/dashboard/summary?state=MT", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetSummary(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response DashboardSummary
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+
+				expectedData, _ := tt.mockGetSummaryFunc()
+				if response.TotalFarms != expectedData.TotalFarms || len(response.FarmsByState) != 2 || response.AreaDistribution != expectedData.AreaDistribution {
+					t.Errorf("Handler returned unexpected body: got %+v want %+v", response, expectedData)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetProductionByCulture(t *testing.T) {
+	yield := 60.0
+
+	// Test cases
+	tests := []struct {
+		name                  string
+		mockGetProductionFunc func() ([]models.CultureProduction, error)
+		expectedStatus        int
+	}{
+		{
+			name: "Success",
+			mockGetProductionFunc: func() ([]models.CultureProduction, error) {
+				return []models.CultureProduction{
+					{Culture: "Soja", Unit: models.UnitSacas, Harvests: 2, PlantedArea: 100, Quantity: 6000, YieldPerHectare: &yield},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Service Error",
+			mockGetProductionFunc: func() ([]models.CultureProduction, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockDashboardService{
+				GetProductionFunc: tt.mockGetProductionFunc,
+			}
+			handler := NewDashboardHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/dashboard/production?year=2024", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetProductionByCulture(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []models.CultureProduction
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+				if len(response) != 1 || response[0].YieldPerHectare == nil || *response[0].YieldPerHectare != yield {
+					t.Errorf("Handler returned unexpected body: %+v", response)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_GetProductionBySeason(t *testing.T) {
+	yield := 55.5
+
+	// Test cases
+	tests := []struct {
+		name                        string
+		mockGetSeasonProductionFunc func() ([]models.SeasonProduction, error)
+		expectedStatus              int
+	}{
+		{
+			name: "Success",
+			mockGetSeasonProductionFunc: func() ([]models.SeasonProduction, error) {
+				return []models.SeasonProduction{
+					{
+						SeasonID: 1,
+						Season:   "Safra 2023/24",
+						Cycle:    models.CycleSafra,
+						CultureProduction: models.CultureProduction{
+							Culture: "Soja", Unit: models.UnitSacas, Harvests: 1, PlantedArea: 200, Quantity: 11100, YieldPerHectare: &yield,
+						},
+					},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Service Error",
+			mockGetSeasonProductionFunc: func() ([]models.SeasonProduction, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockDashboardService{
+				GetSeasonProductionFunc: tt.mockGetSeasonProductionFunc,
+			}
+			handler := NewDashboardHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/dashboard/production/seasons?culture=Soja", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetProductionBySeason(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response []map[string]interface{}
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+				if len(response) != 1 || response[0]["season"] != "Safra 2023/24" || response[0]["culture"] != "Soja" {
+					t.Errorf("Handler returned unexpected body: %+v", response)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardHandler_InvalidFilter(t *testing.T) {
+	handler := NewDashboardHandler(&MockDashboardService{})
+
+	calls := map[string]func(w http.ResponseWriter, r *http.Request){
+		"GetDashboardData":    handler.GetDashboardData,
+		"GetFarmsByState":     handler.GetFarmsByState,
+		"GetHarvestTypes":     handler.GetHarvestTypes,
+		"GetAreaDistribution": handler.GetAreaDistribution,
+		"GetSummary":          handler.GetSummary,
+		"GetProduction":       handler.GetProductionByCulture,
+		"GetSeasonProduction": handler.GetProductionBySeason,
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/dashboard?year=abc", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			call(rr, req)
+
+			if status := rr.Code; status != http.StatusUnprocessableEntity {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, http.StatusUnprocessableEntity)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// internal/api/handlers/errors.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+// statusFor maps the apperrors kinds to HTTP status codes
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, apperrors.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperrors.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, apperrors.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError is the central error writer for service errors. Known error kinds are
+// answered with their own status and message; anything else becomes a 500 whose
+// detail is prefixed with the operation that failed.
+func writeError(w http.ResponseWriter, r *http.Request, operation string, err error) {
+	status := statusFor(err)
+
+	if status == http.StatusInternalServerError {
+		logger.Error("%s: %v", operation, err)
+		writeProblem(w, r, status, operation+": "+err.Error())
+		return
+	}
+
+	logger.Warn("%s: %v", operation, err)
+	if status == http.StatusUnprocessableEntity {
+		writeValidationProblem(w, r, err)
+		return
+	}
+	writeProblem(w, r, status, err.Error())
+}
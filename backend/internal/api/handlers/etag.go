@@ -0,0 +1,37 @@
+// internal/api/handlers/etag.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/etag"
+)
+
+// Conditional request headers
+const (
+	IfMatchHeader     = "If-Match"
+	IfNoneMatchHeader = "If-None-Match"
+	ETagHeader        = "ETag"
+)
+
+// taggedEntity is implemented by the models that expose an entity tag
+type taggedEntity interface {
+	ETag() string
+}
+
+// writeTaggedEntity encodes entity as JSON with its ETag header. Reads whose
+// If-None-Match already matches the tag are answered with 304 Not Modified.
+func writeTaggedEntity(w http.ResponseWriter, r *http.Request, status int, entity taggedEntity) {
+	tag := entity.ETag()
+	w.Header().Set(ETagHeader, tag)
+
+	if r.Method == http.MethodGet && etag.MatchWeak(r.Header.Get(IfNoneMatchHeader), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(entity)
+}
@@ -0,0 +1,239 @@
+// internal/api/handlers/farm_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type FarmHandler struct {
+	service FarmServiceInterface
+}
+
+func NewFarmHandler(service FarmServiceInterface) *FarmHandler {
+	return &FarmHandler{service: service}
+}
+
+func (h *FarmHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var farm models.Farm
+	if err := json.NewDecoder(r.Body).Decode(&farm); err != nil {
+		logger.Warn("Erro ao decodificar JSON: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	h.create(w, r, &farm)
+}
+
+func (h *FarmHandler) CreateForFarmer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	farmerID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID de fazendeiro inválido ao criar fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var farm models.Farm
+	if err := json.NewDecoder(r.Body).Decode(&farm); err != nil {
+		logger.Warn("Erro ao decodificar JSON: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	owner := uint(farmerID)
+	farm.FarmerID = &owner
+	h.create(w, r, &farm)
+}
+
+func (h *FarmHandler) create(w http.ResponseWriter, r *http.Request, farm *models.Farm) {
+	if err := farm.Validate(); err != nil {
+		logger.Warn("Erro de validação ao criar fazenda: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	createdFarm, err := h.service.Create(r.Context(), farm)
+	if err != nil {
+		writeError(w, r, "Erro ao criar fazenda", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusCreated, createdFarm)
+}
+
+func (h *FarmHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var farm models.Farm
+	if err := json.NewDecoder(r.Body).Decode(&farm); err != nil {
+		logger.Warn("Erro ao decodificar JSON na atualização de fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	farm.ID = uint(id)
+	if err := farm.Validate(); err != nil {
+		logger.Warn("Erro de validação ao atualizar fazenda: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	updatedFarm, err := h.service.Update(r.Context(), &farm, r.Header.Get(IfMatchHeader))
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar fazenda", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, updatedFarm)
+}
+
+func (h *FarmHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar parcialmente fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	updatedFarm, err := h.service.Patch(r.Context(), uint(id), patch, r.Header.Get(IfMatchHeader))
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar fazenda", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, updatedFarm)
+}
+
+func (h *FarmHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao excluir fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), uint(id), r.Header.Get(IfMatchHeader)); err != nil {
+		writeError(w, r, "Erro ao excluir fazenda", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FarmHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao buscar fazenda: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	farm, err := h.service.GetByID(uint(id))
+	if err != nil {
+		writeError(w, r, "Erro ao buscar fazenda", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, farm)
+}
+
+func (h *FarmHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	if usesCursor(r) {
+		h.getPage(w, r)
+		return
+	}
+
+	// Parse pagination parameters from query string
+	params := parsePaginationParams(r)
+
+	// Get paginated results from service
+	result, err := h.service.GetAll(params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar fazendas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *FarmHandler) GetByFarmer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	farmerID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID de fazendeiro inválido ao buscar fazendas: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetAllByFarmer(uint(farmerID), params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar fazendas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetNearby lists the farms within radiusKm of lat and lon, nearest first
+func (h *FarmHandler) GetNearby(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseNearbyFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar fazendas próximas: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetNearby(filter, params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar fazendas próximas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// getPage serves the farm list in keyset pagination mode
+func (h *FarmHandler) getPage(w http.ResponseWriter, r *http.Request) {
+	params, err := parseCursorParams(r)
+	if err != nil {
+		logger.Warn("Cursor inválido ao buscar fazendas: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	result, err := h.service.GetPage(params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar fazendas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,824 @@
+// internal/api/handlers/farm_handler_test.go
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/services"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+// MockFarmService is a mock implementation of the FarmServiceInterface
+type MockFarmService struct {
+	CreateFunc         func(farm *models.Farm) (*models.Farm, error)
+	UpdateFunc         func(farm *models.Farm) (*models.Farm, error)
+	PatchFunc          func(id uint, patch []byte) (*models.Farm, error)
+	DeleteFunc         func(id uint) error
+	GetByIDFunc        func(id uint) (*models.Farm, error)
+	GetAllFunc         func(params models.PaginationParams) (models.PaginatedResult, error)
+	GetPageFunc        func(params models.CursorParams) (models.CursorResult, error)
+	GetAllByFarmerFunc func(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error)
+	GetNearbyFunc      func(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+func (m *MockFarmService) Create(ctx context.Context, farm *models.Farm) (*models.Farm, error) {
+	return m.CreateFunc(farm)
+}
+
+func (m *MockFarmService) Update(ctx context.Context, farm *models.Farm, ifMatch string) (*models.Farm, error) {
+	return m.UpdateFunc(farm)
+}
+
+func (m *MockFarmService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farm, error) {
+	return m.PatchFunc(id, patch)
+}
+
+func (m *MockFarmService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return m.DeleteFunc(id)
+}
+
+func (m *MockFarmService) GetByID(id uint) (*models.Farm, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockFarmService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetAllFunc(params)
+}
+
+func (m *MockFarmService) GetPage(params models.CursorParams) (models.CursorResult, error) {
+	return m.GetPageFunc(params)
+}
+
+func (m *MockFarmService) GetAllByFarmer(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetAllByFarmerFunc(farmerID, params)
+}
+
+func (m *MockFarmService) GetNearby(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetNearbyFunc(filter, params)
+}
+
+func validFarm() models.Farm {
+	return models.Farm{
+		Name:            "Fazenda Boa Vista",
+		City:            "Sorriso",
+		State:           "MT",
+		TotalArea:       100,
+		AgricultureArea: 70,
+		VegetationArea:  30,
+	}
+}
+
+func TestFarmHandler_Create(t *testing.T) {
+	invalidFarm := validFarm()
+	invalidFarm.VegetationArea = 50
+
+	openBoundary := validFarm()
+	openBoundary.Boundary = geo.NewPolygon(geo.Ring{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.54}})
+
+	seasonOne, seasonTwo := uint(1), uint(2)
+	separateSeasons := validFarm()
+	separateSeasons.Harvests = []models.Harvest{
+		{Year: 2024, Culture: "Soja", PlantedArea: 50, SeasonID: &seasonOne},
+		{Year: 2024, Culture: "Milho", PlantedArea: 50, SeasonID: &seasonTwo},
+	}
+
+	harvestWithoutSeason := validFarm()
+	harvestWithoutSeason.Harvests = []models.Harvest{
+		{Year: 2024, Culture: "Soja", PlantedArea: 50, SeasonID: &seasonOne},
+		{Year: 2024, Culture: "Milho", PlantedArea: 50, SeasonID: &seasonTwo},
+		{Year: 2024, Culture: "Feijão", PlantedArea: 30},
+	}
+
+	// Test cases
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockCreateFunc func(farm *models.Farm) (*models.Farm, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			requestBody: validFarm(),
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				farm.ID = 1
+				return farm, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "Invalid JSON",
+			requestBody: "invalid json",
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Validation Error",
+			requestBody: invalidFarm,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Invalid Boundary",
+			requestBody: openBoundary,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Unsupported Boundary Type",
+			requestBody: `{"farmName":"Fazenda Boa Vista","city":"Sorriso","state":"MT","totalArea":100,"arableArea":70,"vegetationArea":30,"boundary":{"type":"Point","coordinates":[-55.7,-12.55]}}`,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Harvests Of Separate Seasons",
+			requestBody: separateSeasons,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				farm.ID = 1
+				return farm, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "Harvest Without Season Exceeds A Season Of Its Year",
+			requestBody: harvestWithoutSeason,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Centroid Without Longitude",
+			requestBody: `{"farmName":"Fazenda Boa Vista","city":"Sorriso","state":"MT","totalArea":100,"arableArea":70,"vegetationArea":30,"centroidLat":-12.55}`,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Centroid Out Of Range",
+			requestBody: `{"farmName":"Fazenda Boa Vista","city":"Sorriso","state":"MT","totalArea":100,"arableArea":70,"vegetationArea":30,"centroidLat":-12.55,"centroidLon":-255.7}`,
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Farmer Not Found",
+			requestBody: validFarm(),
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, services.ErrFarmerNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Service Error",
+			requestBody: validFarm(),
+			mockCreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmService{
+				CreateFunc: tt.mockCreateFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			// Create request
+			var reqBody []byte
+			var err error
+			if str, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, err = json.Marshal(tt.requestBody)
+				if err != nil {
+					t.Fatalf("Failed to marshal request body: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest("POST", "/api/farms", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Create(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestFarmHandler_CreateForFarmer(t *testing.T) {
+	var receivedFarmerID *uint
+	mockService := &MockFarmService{
+		CreateFunc: func(farm *models.Farm) (*models.Farm, error) {
+			receivedFarmerID = farm.FarmerID
+			farm.ID = 1
+			return farm, nil
+		},
+	}
+	handler := NewFarmHandler(mockService)
+
+	reqBody, err := json.Marshal(validFarm())
+	if err != nil {
+		t.Fatalf("Failed to marshal request body: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "/api/farmers/7/farms", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": "7"})
+
+	rr := httptest.NewRecorder()
+	handler.CreateForFarmer(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("Handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	if receivedFarmerID == nil || *receivedFarmerID != 7 {
+		t.Errorf("Handler did not assign the farmer from the URL: got %v", receivedFarmerID)
+	}
+}
+
+func TestFarmHandler_GetByID(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		farmID          string
+		mockGetByIDFunc func(id uint) (*models.Farm, error)
+		expectedStatus  int
+	}{
+		{
+			name:   "Success",
+			farmID: "1",
+			mockGetByIDFunc: func(id uint) (*models.Farm, error) {
+				farm := validFarm()
+				farm.ID = id
+				return &farm, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Invalid ID",
+			farmID: "invalid",
+			mockGetByIDFunc: func(id uint) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service Error",
+			farmID: "999",
+			mockGetByIDFunc: func(id uint) (*models.Farm, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmService{
+				GetByIDFunc: tt.mockGetByIDFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("GET", "/api/farms/"+tt.farmID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.GetByID(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestFarmHandler_GetByID_Boundary(t *testing.T) {
+	measured := 120.96
+	mockService := &MockFarmService{
+		GetByIDFunc: func(id uint) (*models.Farm, error) {
+			farm := validFarm()
+			farm.ID = id
+			farm.Boundary = geo.NewPolygon(geo.Ring{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.54}, {-55.7, -12.55}})
+			farm.BoundaryArea = &measured
+			return &farm, nil
+		},
+	}
+	handler := NewFarmHandler(mockService)
+
+	req, err := http.NewRequest("GET", "/api/farms/1", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+	rr := httptest.NewRecorder()
+	handler.GetByID(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var response struct {
+		Boundary        *geo.Geometry `json:"boundary"`
+		BoundaryArea    *float64      `json:"boundaryArea"`
+		AreaDiscrepancy bool          `json:"areaDiscrepancy"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response body: %v", err)
+	}
+	if response.Boundary == nil || response.Boundary.Type != geo.TypePolygon {
+		t.Errorf("Handler did not return the boundary: %+v", response.Boundary)
+	}
+	if response.BoundaryArea == nil || *response.BoundaryArea != measured {
+		t.Errorf("Handler returned boundaryArea %v, want %v", response.BoundaryArea, measured)
+	}
+	// 120.96 ha measured against 100 ha declared is beyond the tolerance
+	if !response.AreaDiscrepancy {
+		t.Errorf("Handler did not flag the area discrepancy")
+	}
+}
+
+func TestFarmHandler_GetByFarmer(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name                   string
+		farmerID               string
+		mockGetAllByFarmerFunc func(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error)
+		expectedStatus         int
+	}{
+		{
+			name:     "Success",
+			farmerID: "1",
+			mockGetAllByFarmerFunc: func(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.NewPaginatedResult([]models.Farm{validFarm()}, 1, params), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "Invalid ID",
+			farmerID: "invalid",
+			mockGetAllByFarmerFunc: func(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Farmer Not Found",
+			farmerID: "999",
+			mockGetAllByFarmerFunc: func(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, services.ErrFarmerNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmService{
+				GetAllByFarmerFunc: tt.mockGetAllByFarmerFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("GET", "/api/farmers/"+tt.farmerID+"/farms", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmerID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.GetByFarmer(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestFarmHandler_GetNearby(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mockError      error
+		expectedStatus int
+		expectedFilter models.NearbyFilter
+		expectedParams models.PaginationParams
+	}{
+		{
+			name:           "Success",
+			query:          "?lat=-12.55&lon=-55.7&radiusKm=50&culture=Soja&page=2&limit=5",
+			expectedStatus: http.StatusOK,
+			expectedFilter: models.NearbyFilter{
+				DashboardFilter: models.DashboardFilter{Culture: "Soja"},
+				Lat:             -12.55,
+				Lon:             -55.7,
+				RadiusKm:        50,
+			},
+			expectedParams: models.PaginationParams{Page: 2, Limit: 5},
+		},
+		{
+			name:           "Default Radius",
+			query:          "?lat=-12.55&lon=-55.7",
+			expectedStatus: http.StatusOK,
+			expectedFilter: models.NearbyFilter{Lat: -12.55, Lon: -55.7, RadiusKm: models.DefaultNearbyRadius},
+			expectedParams: models.PaginationParams{Page: 1, Limit: 10},
+		},
+		{
+			name:           "Missing Position",
+			query:          "?radiusKm=10",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Invalid Radius",
+			query:          "?lat=-12.55&lon=-55.7&radiusKm=0",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Service Error",
+			query:          "?lat=-12.55&lon=-55.7",
+			mockError:      errors.New("service error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter models.NearbyFilter
+			var gotParams models.PaginationParams
+			mockService := &MockFarmService{
+				GetNearbyFunc: func(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+					gotFilter, gotParams = filter, params
+					if tt.mockError != nil {
+						return models.PaginatedResult{}, tt.mockError
+					}
+					items := []models.NearbyFarm{{Farm: validFarm(), Distance: 12.5}}
+					return models.NewPaginatedResult(items, 1, params), nil
+				},
+			}
+			handler := NewFarmHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/farms/nearby"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetNearby(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if !reflect.DeepEqual(gotFilter, tt.expectedFilter) || gotParams != tt.expectedParams {
+				t.Errorf("Service called with %+v and %+v, want %+v and %+v", gotFilter, gotParams, tt.expectedFilter, tt.expectedParams)
+			}
+
+			var response struct {
+				Items []struct {
+					Farm       models.Farm `json:"farm"`
+					DistanceKm float64     `json:"distanceKm"`
+				} `json:"items"`
+				Total int64 `json:"total"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Total != 1 || len(response.Items) != 1 || response.Items[0].DistanceKm != 12.5 || response.Items[0].Farm.Name != validFarm().Name {
+				t.Errorf("Unexpected response: %s", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestFarmHandler_GetAll_Cursor(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		mockGetPageFunc func(params models.CursorParams) (models.CursorResult, error)
+		expectedStatus  int
+	}{
+		{
+			name:  "Backward Page",
+			query: "?cursor=" + cursor.Encode(cursor.Cursor{ID: 10, Backward: true}),
+			mockGetPageFunc: func(params models.CursorParams) (models.CursorResult, error) {
+				if !params.Cursor.Backward || params.Cursor.ID != 10 {
+					t.Errorf("Unexpected cursor: %+v", params.Cursor)
+				}
+				return models.CursorResult{Items: []models.Farm{{ID: 9}}, Limit: params.Limit}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Cursor",
+			query:          "?cursor=%7B%7D",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Service Error",
+			query: "?cursor=",
+			mockGetPageFunc: func(params models.CursorParams) (models.CursorResult, error) {
+				return models.CursorResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockFarmService{
+				GetPageFunc: tt.mockGetPageFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/farms"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetAll(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestFarmHandler_Update(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		farmID         string
+		requestBody    interface{}
+		mockUpdateFunc func(farm *models.Farm) (*models.Farm, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			farmID:      "1",
+			requestBody: validFarm(),
+			mockUpdateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return farm, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Invalid ID",
+			farmID:      "invalid",
+			requestBody: validFarm(),
+			mockUpdateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Validation Error",
+			farmID:      "1",
+			requestBody: models.Farm{Name: "Sem área"},
+			mockUpdateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Service Error",
+			farmID:      "1",
+			requestBody: validFarm(),
+			mockUpdateFunc: func(farm *models.Farm) (*models.Farm, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmService{
+				UpdateFunc: tt.mockUpdateFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			// Create request
+			reqBody, err := json.Marshal(tt.requestBody)
+			if err != nil {
+				t.Fatalf("Failed to marshal request body: %v", err)
+			}
+
+			req, err := http.NewRequest("PUT", "/api/farms/"+tt.farmID, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Update(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestFarmHandler_Delete(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		farmID         string
+		mockDeleteFunc func(id uint) error
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			farmID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "Invalid ID",
+			farmID: "invalid",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service Error",
+			farmID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmService{
+				DeleteFunc: tt.mockDeleteFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("DELETE", "/api/farms/"+tt.farmID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Delete(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestFarmHandler_Patch(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		id             string
+		contentType    string
+		body           string
+		mockPatchFunc  func(id uint, patch []byte) (*models.Farm, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			body:        `{"totalArea":120}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farm, error) {
+				return &models.Farm{ID: id, Name: "Fazenda", City: "Campinas", State: "SP", TotalArea: 120, AgricultureArea: 60, VegetationArea: 40, Version: 2}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Invalid ID",
+			id:          "invalid",
+			contentType: "application/merge-patch+json",
+			body:        `{"totalArea":120}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Unsupported Content Type",
+			id:          "1",
+			contentType: "text/plain",
+			body:        `{"totalArea":120}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farm, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "Not Found",
+			id:          "999",
+			contentType: "application/merge-patch+json",
+			body:        `{"totalArea":120}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farm, error) {
+				return nil, services.ErrFarmNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmService{
+				PatchFunc: tt.mockPatchFunc,
+			}
+			handler := NewFarmHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("PATCH", "/api/farms/"+tt.id, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Patch(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
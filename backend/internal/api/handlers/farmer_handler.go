@@ -23,26 +23,23 @@ func (h *FarmerHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var farmer models.Farmer
 	if err := json.NewDecoder(r.Body).Decode(&farmer); err != nil {
 		logger.Warn("Erro ao decodificar JSON: %v", err)
-		http.Error(w, "Erro ao decodificar JSON: "+err.Error(), http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
 		return
 	}
 
 	if err := farmer.Validate(); err != nil {
 		logger.Warn("Erro de validação ao criar fazendeiro: %v", err)
-		http.Error(w, "Erro de validação: "+err.Error(), http.StatusBadRequest)
+		writeValidationProblem(w, r, err)
 		return
 	}
 
-	createdFarmer, err := h.service.Create(&farmer)
+	createdFarmer, err := h.service.Create(r.Context(), &farmer)
 	if err != nil {
-		logger.Error("Erro ao criar fazendeiro: %v", err)
-		http.Error(w, "Erro ao criar fazendeiro: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao criar fazendeiro", err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(createdFarmer)
+	writeTaggedEntity(w, r, http.StatusCreated, createdFarmer)
 }
 
 func (h *FarmerHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -50,33 +47,54 @@ func (h *FarmerHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(vars["id"], 10, 32)
 	if err != nil {
 		logger.Warn("ID inválido ao atualizar fazendeiro: %v", err)
-		http.Error(w, "ID inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
 		return
 	}
 
 	var farmer models.Farmer
 	if err := json.NewDecoder(r.Body).Decode(&farmer); err != nil {
 		logger.Warn("Erro ao decodificar JSON na atualização de fazendeiro: %v", err)
-		http.Error(w, "Erro ao decodificar JSON: "+err.Error(), http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
 		return
 	}
 
 	farmer.ID = uint(id)
 	if err := farmer.Validate(); err != nil {
 		logger.Warn("Erro de validação ao atualizar fazendeiro: %v", err)
-		http.Error(w, "Erro de validação: "+err.Error(), http.StatusBadRequest)
+		writeValidationProblem(w, r, err)
 		return
 	}
 
-	updatedFarmer, err := h.service.Update(&farmer)
+	updatedFarmer, err := h.service.Update(r.Context(), &farmer, r.Header.Get(IfMatchHeader))
 	if err != nil {
-		logger.Error("Erro ao atualizar fazendeiro: %v", err)
-		http.Error(w, "Erro ao atualizar fazendeiro: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao atualizar fazendeiro", err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(updatedFarmer)
+	writeTaggedEntity(w, r, http.StatusOK, updatedFarmer)
+}
+
+func (h *FarmerHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar parcialmente fazendeiro: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	updatedFarmer, err := h.service.Patch(r.Context(), uint(id), patch, r.Header.Get(IfMatchHeader))
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar fazendeiro", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, updatedFarmer)
 }
 
 func (h *FarmerHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -84,13 +102,12 @@ func (h *FarmerHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(vars["id"], 10, 32)
 	if err != nil {
 		logger.Warn("ID inválido ao excluir fazendeiro: %v", err)
-		http.Error(w, "ID inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
 		return
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
-		logger.Error("Erro ao excluir fazendeiro: %v", err)
-		http.Error(w, "Erro ao excluir fazendeiro: "+err.Error(), http.StatusInternalServerError)
+	if err := h.service.Delete(r.Context(), uint(id), r.Header.Get(IfMatchHeader)); err != nil {
+		writeError(w, r, "Erro ao excluir fazendeiro", err)
 		return
 	}
 
@@ -102,45 +119,58 @@ func (h *FarmerHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseUint(vars["id"], 10, 32)
 	if err != nil {
 		logger.Warn("ID inválido ao buscar fazendeiro: %v", err)
-		http.Error(w, "ID inválido", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
 		return
 	}
 
 	farmer, err := h.service.GetByID(uint(id))
 	if err != nil {
-		logger.Error("Erro ao buscar fazendeiro: %v", err)
-		http.Error(w, "Erro ao buscar fazendeiro: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao buscar fazendeiro", err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(farmer)
+	writeTaggedEntity(w, r, http.StatusOK, farmer)
 }
 
 func (h *FarmerHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	// Parse pagination parameters from query string
-	params := models.PaginationParams{
-		Page:  1,
-		Limit: 10,
-	}
+	params := parsePaginationParams(r)
 
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
-			params.Page = page
-		}
+	filter, err := parseFarmerFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar fazendeiros: %v", err)
+		writeValidationProblem(w, r, err)
+		return
 	}
 
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
-			params.Limit = limit
-		}
+	if usesCursor(r) {
+		h.getPage(w, r, filter)
+		return
 	}
 
 	// Get paginated results from service
-	result, err := h.service.GetAll(params)
+	result, err := h.service.GetAll(filter, params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar fazendeiros", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// getPage serves the farmer list in keyset pagination mode
+func (h *FarmerHandler) getPage(w http.ResponseWriter, r *http.Request, filter models.FarmerFilter) {
+	params, err := parseCursorParams(r)
+	if err != nil {
+		logger.Warn("Cursor inválido ao buscar fazendeiros: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	result, err := h.service.GetPage(filter, params)
 	if err != nil {
-		logger.Error("Erro ao buscar todos os fazendeiros: %v", err)
-		http.Error(w, "Erro ao buscar fazendeiros: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, r, "Erro ao buscar fazendeiros", err)
 		return
 	}
 
@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,26 +12,35 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
 )
 
 // MockFarmerService is a mock implementation of the FarmerServiceInterface
 type MockFarmerService struct {
 	CreateFunc  func(farmer *models.Farmer) (*models.Farmer, error)
 	UpdateFunc  func(farmer *models.Farmer) (*models.Farmer, error)
+	PatchFunc   func(id uint, patch []byte) (*models.Farmer, error)
 	DeleteFunc  func(id uint) error
 	GetByIDFunc func(id uint) (*models.Farmer, error)
 	GetAllFunc  func(params models.PaginationParams) (models.PaginatedResult, error)
+	GetPageFunc func(params models.CursorParams) (models.CursorResult, error)
 }
 
-func (m *MockFarmerService) Create(farmer *models.Farmer) (*models.Farmer, error) {
+func (m *MockFarmerService) Create(ctx context.Context, farmer *models.Farmer) (*models.Farmer, error) {
 	return m.CreateFunc(farmer)
 }
 
-func (m *MockFarmerService) Update(farmer *models.Farmer) (*models.Farmer, error) {
+func (m *MockFarmerService) Update(ctx context.Context, farmer *models.Farmer, ifMatch string) (*models.Farmer, error) {
 	return m.UpdateFunc(farmer)
 }
 
-func (m *MockFarmerService) Delete(id uint) error {
+func (m *MockFarmerService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farmer, error) {
+	return m.PatchFunc(id, patch)
+}
+
+func (m *MockFarmerService) Delete(ctx context.Context, id uint, ifMatch string) error {
 	return m.DeleteFunc(id)
 }
 
@@ -38,10 +48,14 @@ func (m *MockFarmerService) GetByID(id uint) (*models.Farmer, error) {
 	return m.GetByIDFunc(id)
 }
 
-func (m *MockFarmerService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+func (m *MockFarmerService) GetAll(filter models.FarmerFilter, params models.PaginationParams) (models.PaginatedResult, error) {
 	return m.GetAllFunc(params)
 }
 
+func (m *MockFarmerService) GetPage(filter models.FarmerFilter, params models.CursorParams) (models.CursorResult, error) {
+	return m.GetPageFunc(params)
+}
+
 func TestFarmerHandler_Create(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -55,7 +69,7 @@ func TestFarmerHandler_Create(t *testing.T) {
 			name: "Success",
 			requestBody: models.Farmer{
 				FarmerName:            "Test Farmer",
-				FederalIdentification: "12345678901",
+				FederalIdentification: "52998224725",
 			},
 			mockCreateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				farmer.ID = 1
@@ -79,13 +93,35 @@ func TestFarmerHandler_Create(t *testing.T) {
 			mockCreateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				return nil, nil
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Invalid Document",
+			requestBody: models.Farmer{
+				FarmerName:            "Test Farmer",
+				FederalIdentification: "11111111111",
+			},
+			mockCreateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Duplicated Document",
+			requestBody: models.Farmer{
+				FarmerName:            "Test Farmer",
+				FederalIdentification: "52998224725",
+			},
+			mockCreateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
+				return nil, apperrors.Conflict("já existe um fazendeiro com este documento")
+			},
+			expectedStatus: http.StatusConflict,
 		},
 		{
 			name: "Service Error",
 			requestBody: models.Farmer{
 				FarmerName:            "Test Farmer",
-				FederalIdentification: "12345678901",
+				FederalIdentification: "52998224725",
 			},
 			mockCreateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				return nil, errors.New("service error")
@@ -148,7 +184,7 @@ func TestFarmerHandler_GetByID(t *testing.T) {
 				return &models.Farmer{
 					ID:                    id,
 					FarmerName:            "Test Farmer",
-					FederalIdentification: "12345678901",
+					FederalIdentification: "52998224725",
 				}, nil
 			},
 			expectedStatus: http.StatusOK,
@@ -165,9 +201,17 @@ func TestFarmerHandler_GetByID(t *testing.T) {
 			name:     "Not Found",
 			farmerID: "999",
 			mockGetByIDFunc: func(id uint) (*models.Farmer, error) {
-				return nil, errors.New("farmer not found")
+				return nil, apperrors.NotFound("fazendeiro não encontrado")
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:     "Database Unavailable",
+			farmerID: "1",
+			mockGetByIDFunc: func(id uint) (*models.Farmer, error) {
+				return nil, apperrors.Unavailable("banco de dados indisponível")
+			},
+			expectedStatus: http.StatusServiceUnavailable,
 		},
 	}
 
@@ -209,41 +253,56 @@ func TestFarmerHandler_GetAll(t *testing.T) {
 	// Test cases
 	tests := []struct {
 		name           string
-		mockGetAllFunc func() ([]models.Farmer, error)
+		query          string
+		mockGetAllFunc func(params models.PaginationParams) (models.PaginatedResult, error)
 		expectedStatus int
 	}{
 		{
 			name: "Success",
-			mockGetAllFunc: func() ([]models.Farmer, error) {
-				return []models.Farmer{
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				farmers := []models.Farmer{
 					{
 						ID:                    1,
 						FarmerName:            "Farmer 1",
-						FederalIdentification: "12345678901",
+						FederalIdentification: "52998224725",
 					},
 					{
 						ID:                    2,
 						FarmerName:            "Farmer 2",
 						FederalIdentification: "10987654321",
 					},
-				}, nil
+				}
+				return models.NewPaginatedResult(farmers, 2, params), nil
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "Empty List",
-			mockGetAllFunc: func() ([]models.Farmer, error) {
-				return []models.Farmer{}, nil
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.NewPaginatedResult([]models.Farmer{}, 0, params), nil
 			},
 			expectedStatus: http.StatusOK,
 		},
 		{
 			name: "Service Error",
-			mockGetAllFunc: func() ([]models.Farmer, error) {
-				return nil, errors.New("service error")
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, errors.New("service error")
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:  "Filtered List",
+			query: "?name=joao&state=SP&sort=-totalArea",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.NewPaginatedResult([]models.Farmer{}, 0, params), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Filter",
+			query:          "?minArea=abc",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tt := range tests {
@@ -255,7 +314,7 @@ func TestFarmerHandler_GetAll(t *testing.T) {
 			handler := NewFarmerHandler(mockService)
 
 			// Create request
-			req, err := http.NewRequest("GET", "/api/farmers", nil)
+			req, err := http.NewRequest("GET", "/api/farmers"+tt.query, nil)
 			if err != nil {
 				t.Fatalf("Failed to create request: %v", err)
 			}
@@ -274,6 +333,96 @@ func TestFarmerHandler_GetAll(t *testing.T) {
 	}
 }
 
+func TestFarmerHandler_GetAll_Cursor(t *testing.T) {
+	after := cursor.Encode(cursor.Cursor{ID: 5})
+
+	tests := []struct {
+		name            string
+		query           string
+		mockGetPageFunc func(params models.CursorParams) (models.CursorResult, error)
+		expectedStatus  int
+		expectedCursor  cursor.Cursor
+		expectedTotal   bool
+	}{
+		{
+			name:  "First Page",
+			query: "?cursor=&limit=2",
+			mockGetPageFunc: func(params models.CursorParams) (models.CursorResult, error) {
+				return models.CursorResult{Items: []models.Farmer{{ID: 1}, {ID: 2}}, Limit: params.Limit, NextCursor: cursor.Encode(cursor.Cursor{ID: 2})}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Next Page With Total",
+			query: "?cursor=" + after + "&total=true",
+			mockGetPageFunc: func(params models.CursorParams) (models.CursorResult, error) {
+				return models.CursorResult{Items: []models.Farmer{}, Limit: params.Limit}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCursor: cursor.Cursor{ID: 5},
+			expectedTotal:  true,
+		},
+		{
+			name:           "Invalid Cursor",
+			query:          "?cursor=not-a-cursor",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "Invalid Total",
+			query:          "?cursor=&total=maybe",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Service Error",
+			query: "?cursor=",
+			mockGetPageFunc: func(params models.CursorParams) (models.CursorResult, error) {
+				return models.CursorResult{}, validation.Errors{{Field: "sort", Code: validation.CodeInvalid, Message: "a ordenação não é suportada na paginação por cursor"}}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received models.CursorParams
+			mockService := &MockFarmerService{
+				GetPageFunc: func(params models.CursorParams) (models.CursorResult, error) {
+					received = params
+					return tt.mockGetPageFunc(params)
+				},
+			}
+			handler := NewFarmerHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/farmers"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetAll(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.mockGetPageFunc == nil || tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if received.Cursor != tt.expectedCursor || received.WithTotal != tt.expectedTotal {
+				t.Errorf("Unexpected cursor params: %+v", received)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if _, ok := body["page"]; ok {
+				t.Errorf("Cursor response should not carry offset pagination fields: %v", body)
+			}
+		})
+	}
+}
+
 func TestFarmerHandler_Update(t *testing.T) {
 	// Test cases
 	tests := []struct {
@@ -288,7 +437,7 @@ func TestFarmerHandler_Update(t *testing.T) {
 			farmerID: "1",
 			requestBody: models.Farmer{
 				FarmerName:            "Updated Farmer",
-				FederalIdentification: "12345678901",
+				FederalIdentification: "52998224725",
 			},
 			mockUpdateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				return farmer, nil
@@ -300,7 +449,7 @@ func TestFarmerHandler_Update(t *testing.T) {
 			farmerID: "invalid",
 			requestBody: models.Farmer{
 				FarmerName:            "Updated Farmer",
-				FederalIdentification: "12345678901",
+				FederalIdentification: "52998224725",
 			},
 			mockUpdateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				return nil, nil
@@ -325,20 +474,32 @@ func TestFarmerHandler_Update(t *testing.T) {
 			mockUpdateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				return nil, nil
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 		},
 		{
 			name:     "Service Error",
 			farmerID: "1",
 			requestBody: models.Farmer{
 				FarmerName:            "Updated Farmer",
-				FederalIdentification: "12345678901",
+				FederalIdentification: "52998224725",
 			},
 			mockUpdateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
 				return nil, errors.New("service error")
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:     "Stale Version",
+			farmerID: "1",
+			requestBody: models.Farmer{
+				FarmerName:            "Updated Farmer",
+				FederalIdentification: "52998224725",
+			},
+			mockUpdateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
+				return nil, apperrors.PreconditionFailed("o registro foi alterado desde a última leitura")
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -410,6 +571,14 @@ func TestFarmerHandler_Delete(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:     "Not Found",
+			farmerID: "999",
+			mockDeleteFunc: func(id uint) error {
+				return apperrors.NotFound("fazendeiro não encontrado")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
 		{
 			name:     "Service Error",
 			farmerID: "1",
@@ -418,6 +587,14 @@ func TestFarmerHandler_Delete(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:     "Stale Version",
+			farmerID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return apperrors.PreconditionFailed("o registro foi alterado desde a última leitura")
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -453,3 +630,252 @@ func TestFarmerHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestFarmerHandler_Create_ValidationProblem(t *testing.T) {
+	mockService := &MockFarmerService{
+		CreateFunc: func(farmer *models.Farmer) (*models.Farmer, error) {
+			return farmer, nil
+		},
+	}
+	handler := NewFarmerHandler(mockService)
+
+	// Invalid document plus an invalid nested farm and harvest
+	farmer := models.Farmer{
+		FarmerName:            "Test Farmer",
+		FederalIdentification: "abcdefghijk",
+		Farms: []models.Farm{
+			{Name: "Fazenda A", City: "Sorriso", State: "MT", TotalArea: 10, AgricultureArea: 5, VegetationArea: 5},
+			{Name: "Fazenda B", City: "Sorriso", State: "MT", TotalArea: 0, Harvests: []models.Harvest{{Culture: "Soja"}}},
+		},
+	}
+	reqBody, err := json.Marshal(farmer)
+	if err != nil {
+		t.Fatalf("Failed to marshal request body: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", "/api/farmers", bytes.NewBuffer(reqBody))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.Create(rr, req)
+
+	if status := rr.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("Handler returned wrong status code: got %v want %v", status, http.StatusUnprocessableEntity)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Handler returned wrong content type: got %q", contentType)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem document: %v", err)
+	}
+
+	fields := make(map[string]string)
+	for _, fe := range problem.Errors {
+		fields[fe.Field] = fe.Code
+	}
+
+	expected := map[string]string{
+		"federalIdentification":     "invalid_characters",
+		"farms[1].totalArea":        "positive",
+		"farms[1].harvests[0].year": "required",
+	}
+	for field, code := range expected {
+		if fields[field] != code {
+			t.Errorf("Expected field %q with code %q, got %q (all errors: %+v)", field, code, fields[field], problem.Errors)
+		}
+	}
+	if _, ok := fields["farms[0].totalArea"]; ok {
+		t.Errorf("Valid farm should not be reported: %+v", problem.Errors)
+	}
+}
+
+func TestFarmerHandler_GetByID_ConditionalRequest(t *testing.T) {
+	farmer := &models.Farmer{ID: 1, FarmerName: "Test Farmer", FederalIdentification: "52998224725", Version: 3}
+	mockService := &MockFarmerService{
+		GetByIDFunc: func(id uint) (*models.Farmer, error) {
+			return farmer, nil
+		},
+	}
+	handler := NewFarmerHandler(mockService)
+
+	tests := []struct {
+		name           string
+		ifNoneMatch    string
+		expectedStatus int
+	}{
+		{name: "No Precondition", ifNoneMatch: "", expectedStatus: http.StatusOK},
+		{name: "Matching Tag", ifNoneMatch: farmer.ETag(), expectedStatus: http.StatusNotModified},
+		{name: "Weak Matching Tag", ifNoneMatch: "W/" + farmer.ETag(), expectedStatus: http.StatusNotModified},
+		{name: "Outdated Tag", ifNoneMatch: `"outdated"`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/farmers/1", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(IfNoneMatchHeader, tt.ifNoneMatch)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			handler.GetByID(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if got := rr.Header().Get(ETagHeader); got != farmer.ETag() {
+				t.Errorf("Handler returned wrong ETag: got %q want %q", got, farmer.ETag())
+			}
+			if tt.expectedStatus == http.StatusNotModified && rr.Body.Len() != 0 {
+				t.Errorf("304 response should not have a body, got %q", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestFarmerHandler_Patch(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		farmerID       string
+		contentType    string
+		body           string
+		mockPatchFunc  func(id uint, patch []byte) (*models.Farmer, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			farmerID:    "1",
+			contentType: "application/merge-patch+json",
+			body:        `{"farmerName":"Novo Nome"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				if string(patch) != `{"farmerName":"Novo Nome"}` {
+					return nil, errors.New("patch not forwarded")
+				}
+				return &models.Farmer{ID: id, FarmerName: "Novo Nome", FederalIdentification: "52998224725", Version: 2}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Plain JSON Accepted",
+			farmerID:    "1",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"farmerName":"Novo Nome"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return &models.Farmer{ID: id, FarmerName: "Novo Nome", FederalIdentification: "52998224725", Version: 2}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Invalid ID",
+			farmerID:    "invalid",
+			contentType: "application/merge-patch+json",
+			body:        `{"farmerName":"Novo Nome"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid JSON",
+			farmerID:    "1",
+			contentType: "application/merge-patch+json",
+			body:        `{"farmerName":`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Patch Is Not An Object",
+			farmerID:    "1",
+			contentType: "application/merge-patch+json",
+			body:        `["farmerName"]`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "JSON Patch Not Supported",
+			farmerID:    "1",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/farmerName","value":"Novo Nome"}]`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "Merged Result Invalid",
+			farmerID:    "1",
+			contentType: "application/merge-patch+json",
+			body:        `{"farmerName":null}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				var errs validation.Errors
+				errs.Add("farmerName", validation.CodeRequired, "nome do fazendeiro é obrigatório")
+				return nil, errs.Err()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Not Found",
+			farmerID:    "999",
+			contentType: "application/merge-patch+json",
+			body:        `{"farmerName":"Novo Nome"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return nil, apperrors.NotFound("fazendeiro não encontrado")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Stale Version",
+			farmerID:    "1",
+			contentType: "application/merge-patch+json",
+			body:        `{"farmerName":"Novo Nome"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Farmer, error) {
+				return nil, apperrors.PreconditionFailed("o registro foi alterado desde a última leitura")
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockFarmerService{
+				PatchFunc: tt.mockPatchFunc,
+			}
+			handler := NewFarmerHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("PATCH", "/api/farmers/"+tt.farmerID, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmerID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Patch(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus == http.StatusOK && rr.Header().Get(ETagHeader) == "" {
+				t.Error("Handler should return the ETag of the patched farmer")
+			}
+		})
+	}
+}
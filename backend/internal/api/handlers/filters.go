@@ -0,0 +1,220 @@
+// internal/api/handlers/filters.go
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/document"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// parseFarmerFilter reads the farmer list filters and sort order from the query string
+func parseFarmerFilter(r *http.Request) (models.FarmerFilter, error) {
+	query := r.URL.Query()
+	var errs validation.Errors
+
+	filter := models.FarmerFilter{
+		Name:     strings.TrimSpace(query.Get("name")),
+		Document: strings.TrimSpace(query.Get("document")),
+		State:    strings.TrimSpace(query.Get("state")),
+		City:     strings.TrimSpace(query.Get("city")),
+		Culture:  strings.TrimSpace(query.Get("culture")),
+		MinArea:  parseFloatParam(query, "minArea", &errs),
+		MaxArea:  parseFloatParam(query, "maxArea", &errs),
+		Sort:     parseSort(query.Get("sort")),
+	}
+
+	// The document is matched by its digits, so one with none would match every farmer
+	if filter.Document != "" && document.Normalize(filter.Document) == "" {
+		errs.Add("document", validation.CodeInvalid, "document deve conter ao menos um dígito")
+	}
+
+	if filter.MinArea != nil && filter.MaxArea != nil && *filter.MinArea > *filter.MaxArea {
+		errs.Add("maxArea", validation.CodeInvalid, "maxArea deve ser maior ou igual a minArea")
+	}
+
+	return filter, errs.Err()
+}
+
+// parseDashboardFilter reads the slice of farms the dashboard aggregates from the query string
+func parseDashboardFilter(r *http.Request) (models.DashboardFilter, error) {
+	query := r.URL.Query()
+	var errs validation.Errors
+
+	filter := models.DashboardFilter{
+		State:   strings.TrimSpace(query.Get("state")),
+		City:    strings.TrimSpace(query.Get("city")),
+		Culture: strings.TrimSpace(query.Get("culture")),
+	}
+
+	if raw := strings.TrimSpace(query.Get("year")); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year <= 0 {
+			errs.Add("year", validation.CodeInvalid, "year deve ser um ano válido")
+		} else {
+			filter.Year = &year
+		}
+	}
+
+	filter.SeasonID = parseIDParam(query, "seasonId", &errs)
+	filter.FarmerID = parseIDParam(query, "farmerId", &errs)
+
+	return filter, errs.Err()
+}
+
+// parseMapFilter reads the dashboard filters plus the bounding box, the geometry drawn
+// for each farm and the simplification tolerance of the map
+func parseMapFilter(r *http.Request) (models.MapFilter, error) {
+	query := r.URL.Query()
+	var errs validation.Errors
+
+	dashboard, err := parseDashboardFilter(r)
+	errs.Nest("", err)
+	filter := models.MapFilter{DashboardFilter: dashboard}
+
+	if raw := strings.TrimSpace(query.Get("bbox")); raw != "" {
+		box, err := geo.ParseBBox(raw)
+		if err != nil {
+			errs.Add("bbox", validation.CodeInvalid, err.Error())
+		} else {
+			filter.BBox = &box
+		}
+	}
+
+	switch strings.TrimSpace(query.Get("geometry")) {
+	case "", "boundary":
+	case "centroid":
+		filter.Centroids = true
+	default:
+		errs.Add("geometry", validation.CodeInvalid, "geometry deve ser boundary ou centroid")
+	}
+
+	if simplify := parseFloatParam(query, "simplify", &errs); simplify != nil {
+		if *simplify < 0 {
+			errs.Add("simplify", validation.CodeNonNegative, "simplify não pode ser negativo")
+		} else {
+			filter.Simplify = *simplify
+		}
+	}
+
+	return filter, errs.Err()
+}
+
+// parseNearbyFilter reads the searched position, the radius in kilometers and the
+// dashboard filters of the nearby search
+func parseNearbyFilter(r *http.Request) (models.NearbyFilter, error) {
+	query := r.URL.Query()
+	var errs validation.Errors
+
+	dashboard, err := parseDashboardFilter(r)
+	errs.Nest("", err)
+	filter := models.NearbyFilter{DashboardFilter: dashboard, RadiusKm: models.DefaultNearbyRadius}
+
+	if strings.TrimSpace(query.Get("lat")) == "" {
+		errs.Add("lat", validation.CodeRequired, "lat é obrigatório")
+	} else if lat := parseFloatParam(query, "lat", &errs); lat != nil {
+		if *lat >= -90 && *lat <= 90 {
+			filter.Lat = *lat
+		} else {
+			errs.Add("lat", validation.CodeInvalid, "lat deve estar entre -90 e 90")
+		}
+	}
+
+	if strings.TrimSpace(query.Get("lon")) == "" {
+		errs.Add("lon", validation.CodeRequired, "lon é obrigatório")
+	} else if lon := parseFloatParam(query, "lon", &errs); lon != nil {
+		if *lon >= -180 && *lon <= 180 {
+			filter.Lon = *lon
+		} else {
+			errs.Add("lon", validation.CodeInvalid, "lon deve estar entre -180 e 180")
+		}
+	}
+
+	if radius := parseFloatParam(query, "radiusKm", &errs); radius != nil {
+		switch {
+		case !(*radius > 0):
+			errs.Add("radiusKm", validation.CodePositive, "radiusKm deve ser maior que zero")
+		case *radius > models.MaxNearbyRadius:
+			errs.Add("radiusKm", validation.CodeExceeded, fmt.Sprintf("radiusKm não pode ser maior que %g", models.MaxNearbyRadius))
+		default:
+			filter.RadiusKm = *radius
+		}
+	}
+
+	return filter, errs.Err()
+}
+
+// parseIDParam returns nil when the parameter is absent and records an error when it
+// is not a valid ID
+func parseIDParam(query url.Values, name string, errs *validation.Errors) *uint {
+	raw := strings.TrimSpace(query.Get(name))
+	if raw == "" {
+		return nil
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		errs.Add(name, validation.CodeInvalid, name+" deve ser um ID válido")
+		return nil
+	}
+	value := uint(id)
+	return &value
+}
+
+// parseFloatParam returns nil when the parameter is absent and records an error when it
+// is not a finite number. ParseFloat also accepts "NaN" and "Inf", which no filter means.
+func parseFloatParam(query url.Values, name string, errs *validation.Errors) *float64 {
+	raw := strings.TrimSpace(query.Get(name))
+	if raw == "" {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		errs.Add(name, validation.CodeInvalid, name+" deve ser um número")
+		return nil
+	}
+	return &value
+}
+
+// parseBoolParam returns false when the parameter is absent and records an error when
+// it is not a boolean
+func parseBoolParam(query url.Values, name string, errs *validation.Errors) bool {
+	raw := strings.TrimSpace(query.Get(name))
+	if raw == "" {
+		return false
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		errs.Add(name, validation.CodeInvalid, name+" deve ser true ou false")
+		return false
+	}
+	return value
+}
+
+// parseSort splits a sort parameter such as "farmerName,-createdAt" into its fields.
+// Which fields are allowed is decided by the repository.
+func parseSort(raw string) []models.SortField {
+	var fields []models.SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := models.SortField{Field: part}
+		if strings.HasPrefix(part, "-") {
+			field = models.SortField{Field: strings.TrimPrefix(part, "-"), Desc: true}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
@@ -0,0 +1,339 @@
+// internal/api/handlers/filters_test.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+func TestParseFarmerFilter(t *testing.T) {
+	minArea, maxArea := 10.0, 250.5
+
+	tests := []struct {
+		name        string
+		query       string
+		expected    models.FarmerFilter
+		expectError bool
+	}{
+		{
+			name:     "No Filters",
+			query:    "",
+			expected: models.FarmerFilter{},
+		},
+		{
+			name:  "All Filters",
+			query: "?name=%20jo%C3%A3o%20&document=529.982&state=sp&city=Campinas&culture=Soja&minArea=10&maxArea=250.5&sort=farmerName,-createdAt",
+			expected: models.FarmerFilter{
+				Name:     "joão",
+				Document: "529.982",
+				State:    "sp",
+				City:     "Campinas",
+				Culture:  "Soja",
+				MinArea:  &minArea,
+				MaxArea:  &maxArea,
+				Sort: []models.SortField{
+					{Field: "farmerName"},
+					{Field: "createdAt", Desc: true},
+				},
+			},
+		},
+		{
+			name:  "Sort Ignores Empty Fields",
+			query: "?sort=,-totalArea,,",
+			expected: models.FarmerFilter{
+				Sort: []models.SortField{{Field: "totalArea", Desc: true}},
+			},
+		},
+		{
+			name:        "Invalid Area",
+			query:       "?minArea=abc",
+			expectError: true,
+		},
+		{
+			name:        "Min Area Greater Than Max Area",
+			query:       "?minArea=100&maxArea=10",
+			expectError: true,
+		},
+		{
+			name:        "Area Not A Number",
+			query:       "?minArea=NaN",
+			expectError: true,
+		},
+		{
+			name:        "Infinite Area",
+			query:       "?maxArea=-Inf",
+			expectError: true,
+		},
+		{
+			name:        "Document Without Digits",
+			query:       "?document=abc",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/farmers"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			filter, err := parseFarmerFilter(req)
+			if tt.expectError {
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("Unexpected filter: got %+v want %+v", filter, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDashboardFilter(t *testing.T) {
+	year := 2024
+	farmerID := uint(7)
+	seasonID := uint(3)
+
+	tests := []struct {
+		name        string
+		query       string
+		expected    models.DashboardFilter
+		expectError bool
+	}{
+		{
+			name:     "No Filters",
+			query:    "",
+			expected: models.DashboardFilter{},
+		},
+		{
+			name:  "All Filters",
+			query: "?state=MT&city=Sorriso&culture=Soja&year=2024&farmerId=7",
+			expected: models.DashboardFilter{
+				State:    "MT",
+				City:     "Sorriso",
+				Culture:  "Soja",
+				Year:     &year,
+				FarmerID: &farmerID,
+			},
+		},
+		{
+			name:     "Season Filter",
+			query:    "?seasonId=3",
+			expected: models.DashboardFilter{SeasonID: &seasonID},
+		},
+		{
+			name:        "Invalid Season ID",
+			query:       "?seasonId=abc",
+			expectError: true,
+		},
+		{
+			name:        "Invalid Year",
+			query:       "?year=last",
+			expectError: true,
+		},
+		{
+			name:        "Invalid Farmer ID",
+			query:       "?farmerId=0",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/dashboard"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			filter, err := parseDashboardFilter(req)
+			if tt.expectError {
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("Unexpected filter: got %+v want %+v", filter, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseMapFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expected    models.MapFilter
+		expectError bool
+	}{
+		{
+			name:     "No Filters",
+			query:    "",
+			expected: models.MapFilter{},
+		},
+		{
+			name:  "All Filters",
+			query: "?state=MT&culture=Soja&bbox=-56.1,-13,-55.5,-12.2&geometry=boundary&simplify=50",
+			expected: models.MapFilter{
+				DashboardFilter: models.DashboardFilter{State: "MT", Culture: "Soja"},
+				BBox:            &geo.BBox{MinLon: -56.1, MinLat: -13, MaxLon: -55.5, MaxLat: -12.2},
+				Simplify:        50,
+			},
+		},
+		{
+			name:     "Centroids",
+			query:    "?geometry=centroid",
+			expected: models.MapFilter{Centroids: true},
+		},
+		{
+			name:        "Invalid BBox",
+			query:       "?bbox=-55.5,-13,-56.1,-12.2",
+			expectError: true,
+		},
+		{
+			name:        "Invalid Geometry",
+			query:       "?geometry=point",
+			expectError: true,
+		},
+		{
+			name:        "Invalid Simplify",
+			query:       "?simplify=far",
+			expectError: true,
+		},
+		{
+			name:        "Infinite Simplify",
+			query:       "?simplify=Inf",
+			expectError: true,
+		},
+		{
+			name:        "Invalid Dashboard Filter",
+			query:       "?farmerId=0",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/map/farms"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			filter, err := parseMapFilter(req)
+			if tt.expectError {
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("Unexpected filter: got %+v want %+v", filter, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseNearbyFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expected    models.NearbyFilter
+		expectError bool
+	}{
+		{
+			name:     "Default Radius",
+			query:    "?lat=-12.55&lon=-55.7",
+			expected: models.NearbyFilter{Lat: -12.55, Lon: -55.7, RadiusKm: models.DefaultNearbyRadius},
+		},
+		{
+			name:  "All Filters",
+			query: "?lat=-12.55&lon=-55.7&radiusKm=12.5&culture=Soja&state=MT",
+			expected: models.NearbyFilter{
+				DashboardFilter: models.DashboardFilter{State: "MT", Culture: "Soja"},
+				Lat:             -12.55,
+				Lon:             -55.7,
+				RadiusKm:        12.5,
+			},
+		},
+		{
+			name:        "Missing Latitude",
+			query:       "?lon=-55.7",
+			expectError: true,
+		},
+		{
+			name:        "Missing Longitude",
+			query:       "?lat=-12.55",
+			expectError: true,
+		},
+		{
+			name:        "Latitude Out Of Range",
+			query:       "?lat=-95&lon=-55.7",
+			expectError: true,
+		},
+		{
+			name:        "Longitude Not A Number",
+			query:       "?lat=-12.55&lon=NaN",
+			expectError: true,
+		},
+		{
+			name:        "Negative Radius",
+			query:       "?lat=-12.55&lon=-55.7&radiusKm=-1",
+			expectError: true,
+		},
+		{
+			name:        "Radius Too Large",
+			query:       "?lat=-12.55&lon=-55.7&radiusKm=501",
+			expectError: true,
+		},
+		{
+			name:        "Invalid Dashboard Filter",
+			query:       "?lat=-12.55&lon=-55.7&year=last",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/farms/nearby"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			filter, err := parseNearbyFilter(req)
+			if tt.expectError {
+				if !errors.Is(err, apperrors.ErrValidation) {
+					t.Fatalf("Expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("Unexpected filter: got %+v want %+v", filter, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// internal/api/handlers/harvest_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type HarvestHandler struct {
+	service HarvestServiceInterface
+}
+
+func NewHarvestHandler(service HarvestServiceInterface) *HarvestHandler {
+	return &HarvestHandler{service: service}
+}
+
+func (h *HarvestHandler) CreateForFarm(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	farmID, err := strconv.ParseUint(vars["farmId"], 10, 32)
+	if err != nil {
+		logger.Warn("ID de fazenda inválido ao criar safra: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var harvest models.Harvest
+	if err := json.NewDecoder(r.Body).Decode(&harvest); err != nil {
+		logger.Warn("Erro ao decodificar JSON: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	farm := uint(farmID)
+	harvest.FarmID = &farm
+	if err := harvest.Validate(); err != nil {
+		logger.Warn("Erro de validação ao criar safra: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	createdHarvest, err := h.service.Create(r.Context(), &harvest)
+	if err != nil {
+		writeError(w, r, "Erro ao criar safra", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusCreated, createdHarvest)
+}
+
+func (h *HarvestHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar safra: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var harvest models.Harvest
+	if err := json.NewDecoder(r.Body).Decode(&harvest); err != nil {
+		logger.Warn("Erro ao decodificar JSON na atualização de safra: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	harvest.ID = uint(id)
+	if err := harvest.Validate(); err != nil {
+		logger.Warn("Erro de validação ao atualizar safra: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	updatedHarvest, err := h.service.Update(r.Context(), &harvest, r.Header.Get(IfMatchHeader))
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar safra", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, updatedHarvest)
+}
+
+func (h *HarvestHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar parcialmente safra: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	patch, ok := readMergePatch(w, r)
+	if !ok {
+		return
+	}
+
+	updatedHarvest, err := h.service.Patch(r.Context(), uint(id), patch, r.Header.Get(IfMatchHeader))
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar safra", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, updatedHarvest)
+}
+
+func (h *HarvestHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao excluir safra: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), uint(id), r.Header.Get(IfMatchHeader)); err != nil {
+		writeError(w, r, "Erro ao excluir safra", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HarvestHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao buscar safra: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	harvest, err := h.service.GetByID(uint(id))
+	if err != nil {
+		writeError(w, r, "Erro ao buscar safra", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, harvest)
+}
+
+func (h *HarvestHandler) GetByFarm(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	farmID, err := strconv.ParseUint(vars["farmId"], 10, 32)
+	if err != nil {
+		logger.Warn("ID de fazenda inválido ao buscar safras: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetAllByFarm(uint(farmID), params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar safras", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,611 @@
+// internal/api/handlers/harvest_handler_test.go
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/services"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// MockHarvestService is a mock implementation of the HarvestServiceInterface
+type MockHarvestService struct {
+	CreateFunc       func(harvest *models.Harvest) (*models.Harvest, error)
+	UpdateFunc       func(harvest *models.Harvest) (*models.Harvest, error)
+	PatchFunc        func(id uint, patch []byte) (*models.Harvest, error)
+	DeleteFunc       func(id uint) error
+	GetByIDFunc      func(id uint) (*models.Harvest, error)
+	GetAllByFarmFunc func(farmID uint, params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+func (m *MockHarvestService) Create(ctx context.Context, harvest *models.Harvest) (*models.Harvest, error) {
+	return m.CreateFunc(harvest)
+}
+
+func (m *MockHarvestService) Update(ctx context.Context, harvest *models.Harvest, ifMatch string) (*models.Harvest, error) {
+	return m.UpdateFunc(harvest)
+}
+
+func (m *MockHarvestService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Harvest, error) {
+	return m.PatchFunc(id, patch)
+}
+
+func (m *MockHarvestService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return m.DeleteFunc(id)
+}
+
+func (m *MockHarvestService) GetByID(id uint) (*models.Harvest, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockHarvestService) GetAllByFarm(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetAllByFarmFunc(farmID, params)
+}
+
+func TestHarvestHandler_CreateForFarm(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		farmID         string
+		requestBody    interface{}
+		mockCreateFunc func(harvest *models.Harvest) (*models.Harvest, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			farmID:      "1",
+			requestBody: models.Harvest{Year: 2024, Culture: "Soja"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				if harvest.FarmID == nil || *harvest.FarmID != 1 {
+					return nil, errors.New("farm not assigned")
+				}
+				harvest.ID = 1
+				return harvest, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "Invalid Farm ID",
+			farmID:      "invalid",
+			requestBody: models.Harvest{Year: 2024, Culture: "Soja"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid JSON",
+			farmID:      "1",
+			requestBody: "invalid json",
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Validation Error",
+			farmID:      "1",
+			requestBody: models.Harvest{Culture: "Soja"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Invalid Unit",
+			farmID:      "1",
+			requestBody: models.Harvest{Year: 2024, Culture: "Soja", PlantedArea: 10, Quantity: 600, Unit: "litros"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Culture Not In Catalog",
+			farmID:      "1",
+			requestBody: models.Harvest{Year: 2024, Culture: "Girassol"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, validation.Errors{{Field: "culture", Code: validation.CodeInvalid, Message: "cultura \"Girassol\" não cadastrada no catálogo"}}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Planted Area Exceeded",
+			farmID:      "1",
+			requestBody: models.Harvest{Year: 2024, Culture: "Soja", PlantedArea: 1000},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, validation.Errors{{Field: "plantedArea", Code: validation.CodeExceeded, Message: models.PlantedAreaExceededMessage}}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Farm Not Found",
+			farmID:      "999",
+			requestBody: models.Harvest{Year: 2024, Culture: "Soja"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, services.ErrFarmNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Service Error",
+			farmID:      "1",
+			requestBody: models.Harvest{Year: 2024, Culture: "Soja"},
+			mockCreateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockHarvestService{
+				CreateFunc: tt.mockCreateFunc,
+			}
+			handler := NewHarvestHandler(mockService)
+
+			// Create request
+			var reqBody []byte
+			var err error
+			if str, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, err = json.Marshal(tt.requestBody)
+				if err != nil {
+					t.Fatalf("Failed to marshal request body: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest("POST", "/api/farms/"+tt.farmID+"/harvests", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"farmId": tt.farmID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.CreateForFarm(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestHarvestHandler_GetByFarm(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name                 string
+		farmID               string
+		mockGetAllByFarmFunc func(farmID uint, params models.PaginationParams) (models.PaginatedResult, error)
+		expectedStatus       int
+	}{
+		{
+			name:   "Success",
+			farmID: "1",
+			mockGetAllByFarmFunc: func(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				harvests := []models.Harvest{{ID: 1, Year: 2024, Culture: "Soja", FarmID: &farmID}}
+				return models.NewPaginatedResult(harvests, 1, params), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Invalid Farm ID",
+			farmID: "invalid",
+			mockGetAllByFarmFunc: func(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Farm Not Found",
+			farmID: "999",
+			mockGetAllByFarmFunc: func(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, services.ErrFarmNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Service Error",
+			farmID: "1",
+			mockGetAllByFarmFunc: func(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockHarvestService{
+				GetAllByFarmFunc: tt.mockGetAllByFarmFunc,
+			}
+			handler := NewHarvestHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("GET", "/api/farms/"+tt.farmID+"/harvests?page=2&limit=5", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"farmId": tt.farmID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.GetByFarm(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			// For successful responses, verify the pagination was forwarded
+			if tt.expectedStatus == http.StatusOK {
+				var response models.PaginatedResult
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Errorf("Failed to decode response body: %v", err)
+				}
+				if response.Page != 2 || response.Limit != 5 {
+					t.Errorf("Handler returned unexpected pagination: got page %d limit %d", response.Page, response.Limit)
+				}
+			}
+		})
+	}
+}
+
+func TestHarvestHandler_GetByID(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		harvestID       string
+		mockGetByIDFunc func(id uint) (*models.Harvest, error)
+		expectedStatus  int
+	}{
+		{
+			name:      "Success",
+			harvestID: "1",
+			mockGetByIDFunc: func(id uint) (*models.Harvest, error) {
+				return &models.Harvest{ID: id, Year: 2024, Culture: "Milho"}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "Invalid ID",
+			harvestID: "invalid",
+			mockGetByIDFunc: func(id uint) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Service Error",
+			harvestID: "1",
+			mockGetByIDFunc: func(id uint) (*models.Harvest, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockHarvestService{
+				GetByIDFunc: tt.mockGetByIDFunc,
+			}
+			handler := NewHarvestHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("GET", "/api/harvests/"+tt.harvestID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.harvestID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.GetByID(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestHarvestHandler_GetByID_Yield(t *testing.T) {
+	tests := []struct {
+		name          string
+		harvest       models.Harvest
+		expectedYield interface{}
+	}{
+		{
+			name:          "With Planted Area",
+			harvest:       models.Harvest{ID: 1, Year: 2024, Culture: "Soja", PlantedArea: 50, Quantity: 3000, Unit: models.UnitSacas},
+			expectedYield: 60.0,
+		},
+		{
+			name:          "Without Planted Area",
+			harvest:       models.Harvest{ID: 2, Year: 2024, Culture: "Milho"},
+			expectedYield: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockHarvestService{
+				GetByIDFunc: func(id uint) (*models.Harvest, error) {
+					return &tt.harvest, nil
+				},
+			}
+			handler := NewHarvestHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/harvests/1", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+
+			rr := httptest.NewRecorder()
+			handler.GetByID(rr, req)
+
+			if status := rr.Code; status != http.StatusOK {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, http.StatusOK)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if yield, ok := body["yieldPerHectare"]; !ok || yield != tt.expectedYield {
+				t.Errorf("Unexpected yieldPerHectare: got %v want %v", body["yieldPerHectare"], tt.expectedYield)
+			}
+		})
+	}
+}
+
+func TestHarvestHandler_Update(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		harvestID      string
+		requestBody    interface{}
+		mockUpdateFunc func(harvest *models.Harvest) (*models.Harvest, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			harvestID:   "1",
+			requestBody: models.Harvest{Year: 2025, Culture: "Café"},
+			mockUpdateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return harvest, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Validation Error",
+			harvestID:   "1",
+			requestBody: models.Harvest{Year: 2025},
+			mockUpdateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Farm Not Found",
+			harvestID:   "1",
+			requestBody: models.Harvest{Year: 2025, Culture: "Café"},
+			mockUpdateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, services.ErrFarmNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "Service Error",
+			harvestID:   "1",
+			requestBody: models.Harvest{Year: 2025, Culture: "Café"},
+			mockUpdateFunc: func(harvest *models.Harvest) (*models.Harvest, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockHarvestService{
+				UpdateFunc: tt.mockUpdateFunc,
+			}
+			handler := NewHarvestHandler(mockService)
+
+			// Create request
+			reqBody, err := json.Marshal(tt.requestBody)
+			if err != nil {
+				t.Fatalf("Failed to marshal request body: %v", err)
+			}
+
+			req, err := http.NewRequest("PUT", "/api/harvests/"+tt.harvestID, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.harvestID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Update(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestHarvestHandler_Delete(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		harvestID      string
+		mockDeleteFunc func(id uint) error
+		expectedStatus int
+	}{
+		{
+			name:      "Success",
+			harvestID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "Invalid ID",
+			harvestID: "invalid",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Service Error",
+			harvestID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockHarvestService{
+				DeleteFunc: tt.mockDeleteFunc,
+			}
+			handler := NewHarvestHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("DELETE", "/api/harvests/"+tt.harvestID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.harvestID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Delete(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestHarvestHandler_Patch(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		id             string
+		contentType    string
+		body           string
+		mockPatchFunc  func(id uint, patch []byte) (*models.Harvest, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			id:          "1",
+			contentType: "application/merge-patch+json",
+			body:        `{"culture":"Milho"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Harvest, error) {
+				return &models.Harvest{ID: id, Year: 2024, Culture: "Milho", Version: 2}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Invalid ID",
+			id:          "invalid",
+			contentType: "application/merge-patch+json",
+			body:        `{"culture":"Milho"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Unsupported Content Type",
+			id:          "1",
+			contentType: "text/plain",
+			body:        `{"culture":"Milho"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Harvest, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "Not Found",
+			id:          "999",
+			contentType: "application/merge-patch+json",
+			body:        `{"culture":"Milho"}`,
+			mockPatchFunc: func(id uint, patch []byte) (*models.Harvest, error) {
+				return nil, apperrors.NotFound("safra não encontrada")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockHarvestService{
+				PatchFunc: tt.mockPatchFunc,
+			}
+			handler := NewHarvestHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("PATCH", "/api/harvests/"+tt.id, bytes.NewBufferString(tt.body))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.Patch(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
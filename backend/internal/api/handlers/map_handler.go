@@ -0,0 +1,38 @@
+// internal/api/handlers/map_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+// GeoJSONContentType is the media type of the map responses
+const GeoJSONContentType = "application/geo+json"
+
+type MapHandler struct {
+	service MapServiceInterface
+}
+
+func NewMapHandler(service MapServiceInterface) *MapHandler {
+	return &MapHandler{service: service}
+}
+
+func (h *MapHandler) GetFarms(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseMapFilter(r)
+	if err != nil {
+		logger.Warn("Filtros inválidos ao buscar o mapa de fazendas: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	collection, err := h.service.GetFarms(filter)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar o mapa de fazendas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", GeoJSONContentType)
+	json.NewEncoder(w).Encode(collection)
+}
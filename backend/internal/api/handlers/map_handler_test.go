@@ -0,0 +1,158 @@
+// internal/api/handlers/map_handler_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+// MockMapService is a mock implementation of the MapServiceInterface
+type MockMapService struct {
+	GetFarmsFunc func(filter models.MapFilter) (*geo.FeatureCollection, error)
+}
+
+func (m *MockMapService) GetFarms(filter models.MapFilter) (*geo.FeatureCollection, error) {
+	return m.GetFarmsFunc(filter)
+}
+
+func TestMapHandler_GetFarms(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		mockGetFarmsFunc func(filter models.MapFilter) (*geo.FeatureCollection, error)
+		expectedStatus   int
+		expectedFilter   models.MapFilter
+	}{
+		{
+			name:  "Success",
+			query: "?state=MT&culture=Soja&bbox=-56.1,-13,-55.5,-12.2&simplify=25",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return &geo.FeatureCollection{Features: []geo.Feature{{
+					ID:         uint(1),
+					Geometry:   geo.NewPolygon(geo.Ring{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.55}}),
+					Properties: models.FarmMapEntry{ID: 1, Name: "Fazenda Boa Vista", Owner: "João", LatestCulture: "Soja"},
+				}}}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedFilter: models.MapFilter{
+				DashboardFilter: models.DashboardFilter{State: "MT", Culture: "Soja"},
+				BBox:            &geo.BBox{MinLon: -56.1, MinLat: -13, MaxLon: -55.5, MaxLat: -12.2},
+				Simplify:        25,
+			},
+		},
+		{
+			name:  "Centroids",
+			query: "?geometry=centroid",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return &geo.FeatureCollection{}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedFilter: models.MapFilter{Centroids: true},
+		},
+		{
+			name:  "Invalid BBox",
+			query: "?bbox=-55.5,-13,-56.1",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Invalid Geometry",
+			query: "?geometry=hexagon",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Negative Simplify",
+			query: "?simplify=-1",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Invalid Dashboard Filter",
+			query: "?year=last",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Service Error",
+			query: "",
+			mockGetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter models.MapFilter
+			mockService := &MockMapService{
+				GetFarmsFunc: func(filter models.MapFilter) (*geo.FeatureCollection, error) {
+					gotFilter = filter
+					return tt.mockGetFarmsFunc(filter)
+				},
+			}
+			handler := NewMapHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/map/farms"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetFarms(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if !reflect.DeepEqual(gotFilter, tt.expectedFilter) {
+				t.Errorf("Service called with %+v, want %+v", gotFilter, tt.expectedFilter)
+			}
+			if contentType := rr.Header().Get("Content-Type"); contentType != GeoJSONContentType {
+				t.Errorf("Handler returned wrong content type: got %q want %q", contentType, GeoJSONContentType)
+			}
+
+			var response struct {
+				Type     string `json:"type"`
+				Features []struct {
+					Type       string                 `json:"type"`
+					Geometry   map[string]interface{} `json:"geometry"`
+					Properties map[string]interface{} `json:"properties"`
+				} `json:"features"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Type != "FeatureCollection" || response.Features == nil {
+				t.Errorf("Handler did not return a FeatureCollection: %s", rr.Body.String())
+			}
+			for _, feature := range response.Features {
+				if feature.Type != "Feature" || feature.Geometry["type"] != geo.TypePolygon {
+					t.Errorf("Unexpected feature: %+v", feature)
+				}
+				if feature.Properties["owner"] != "João" || feature.Properties["latestCulture"] != "Soja" {
+					t.Errorf("Unexpected feature properties: %+v", feature.Properties)
+				}
+			}
+		})
+	}
+}
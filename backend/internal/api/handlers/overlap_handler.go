@@ -0,0 +1,49 @@
+// internal/api/handlers/overlap_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type OverlapHandler struct {
+	service OverlapServiceInterface
+}
+
+func NewOverlapHandler(service OverlapServiceInterface) *OverlapHandler {
+	return &OverlapHandler{service: service}
+}
+
+func (h *OverlapHandler) GetByFarm(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao buscar sobreposições: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	overlaps, err := h.service.GetByFarm(uint(id))
+	if err != nil {
+		writeError(w, r, "Erro ao buscar sobreposições da fazenda", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(overlaps)
+}
+
+func (h *OverlapHandler) Report(w http.ResponseWriter, r *http.Request) {
+	pairs, err := h.service.Report()
+	if err != nil {
+		writeError(w, r, "Erro ao gerar o relatório de sobreposições", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pairs)
+}
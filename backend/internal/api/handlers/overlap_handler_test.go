@@ -0,0 +1,175 @@
+// internal/api/handlers/overlap_handler_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+)
+
+// MockOverlapService is a mock implementation of the OverlapServiceInterface
+type MockOverlapService struct {
+	GetByFarmFunc func(id uint) ([]models.FarmOverlap, error)
+	ReportFunc    func() ([]models.FarmOverlapPair, error)
+}
+
+func (m *MockOverlapService) GetByFarm(id uint) ([]models.FarmOverlap, error) {
+	return m.GetByFarmFunc(id)
+}
+
+func (m *MockOverlapService) Report() ([]models.FarmOverlapPair, error) {
+	return m.ReportFunc()
+}
+
+func TestOverlapHandler_GetByFarm(t *testing.T) {
+	overlaps := []models.FarmOverlap{{FarmID: 2, FarmName: "Fazenda Vizinha", Area: 12.5, Percentage: 10.3}}
+
+	tests := []struct {
+		name              string
+		farmID            string
+		mockGetByFarmFunc func(id uint) ([]models.FarmOverlap, error)
+		expectedStatus    int
+		expectedOverlaps  []models.FarmOverlap
+	}{
+		{
+			name:   "Success",
+			farmID: "1",
+			mockGetByFarmFunc: func(id uint) ([]models.FarmOverlap, error) {
+				if id != 1 {
+					t.Errorf("Service called with farm %d, want 1", id)
+				}
+				return overlaps, nil
+			},
+			expectedStatus:   http.StatusOK,
+			expectedOverlaps: overlaps,
+		},
+		{
+			name:   "No Overlaps",
+			farmID: "1",
+			mockGetByFarmFunc: func(id uint) ([]models.FarmOverlap, error) {
+				return []models.FarmOverlap{}, nil
+			},
+			expectedStatus:   http.StatusOK,
+			expectedOverlaps: []models.FarmOverlap{},
+		},
+		{
+			name:   "Invalid ID",
+			farmID: "invalid",
+			mockGetByFarmFunc: func(id uint) ([]models.FarmOverlap, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Farm Not Found",
+			farmID: "999",
+			mockGetByFarmFunc: func(id uint) ([]models.FarmOverlap, error) {
+				return nil, apperrors.NotFound("fazenda não encontrada")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Service Error",
+			farmID: "1",
+			mockGetByFarmFunc: func(id uint) ([]models.FarmOverlap, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewOverlapHandler(&MockOverlapService{GetByFarmFunc: tt.mockGetByFarmFunc})
+
+			req, err := http.NewRequest("GET", "/api/farms/"+tt.farmID+"/overlaps", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmID})
+
+			rr := httptest.NewRecorder()
+			handler.GetByFarm(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response []models.FarmOverlap
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if !reflect.DeepEqual(response, tt.expectedOverlaps) {
+				t.Errorf("Handler returned %+v, want %+v", response, tt.expectedOverlaps)
+			}
+		})
+	}
+}
+
+func TestOverlapHandler_Report(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockReportFunc func() ([]models.FarmOverlapPair, error)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			mockReportFunc: func() ([]models.FarmOverlapPair, error) {
+				return []models.FarmOverlapPair{{FarmID: 1, FarmName: "Fazenda A", OtherFarmID: 2, OtherFarmName: "Fazenda B", Area: 12.5}}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"farmId":1,"farmName":"Fazenda A","otherFarmId":2,"otherFarmName":"Fazenda B","overlapArea":12.5}]`,
+		},
+		{
+			name: "Empty",
+			mockReportFunc: func() ([]models.FarmOverlapPair, error) {
+				return []models.FarmOverlapPair{}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name: "Service Error",
+			mockReportFunc: func() ([]models.FarmOverlapPair, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewOverlapHandler(&MockOverlapService{ReportFunc: tt.mockReportFunc})
+
+			req, err := http.NewRequest("GET", "/api/farms/overlaps", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.Report(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if body := rr.Body.String(); body != tt.expectedBody+"\n" {
+				t.Errorf("Handler returned %s, want %s", body, tt.expectedBody)
+			}
+		})
+	}
+}
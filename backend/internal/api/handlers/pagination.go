@@ -0,0 +1,64 @@
+// internal/api/handlers/pagination.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// parsePaginationParams reads the page and limit query parameters, falling back to defaults
+func parsePaginationParams(r *http.Request) models.PaginationParams {
+	params := models.PaginationParams{
+		Page:  1,
+		Limit: 10,
+	}
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			params.Page = page
+		}
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			params.Limit = limit
+		}
+	}
+
+	return params
+}
+
+// usesCursor reports whether the client opted into keyset pagination. An empty cursor
+// parameter asks for the first page.
+func usesCursor(r *http.Request) bool {
+	return r.URL.Query().Has("cursor")
+}
+
+// parseCursorParams reads the cursor, limit and total query parameters of keyset pagination
+func parseCursorParams(r *http.Request) (models.CursorParams, error) {
+	query := r.URL.Query()
+	params := models.CursorParams{
+		Limit: parsePaginationParams(r).Limit,
+	}
+
+	var errs validation.Errors
+	c, err := cursor.Decode(query.Get("cursor"))
+	if err != nil {
+		errs.Add("cursor", validation.CodeInvalid, err.Error())
+	}
+	params.Cursor = c
+
+	if totalStr := query.Get("total"); totalStr != "" {
+		withTotal, err := strconv.ParseBool(totalStr)
+		if err != nil {
+			errs.Add("total", validation.CodeInvalid, "total deve ser true ou false")
+		}
+		params.WithTotal = withTotal
+	}
+
+	return params, errs.Err()
+}
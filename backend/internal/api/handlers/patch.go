@@ -0,0 +1,41 @@
+// internal/api/handlers/patch.go
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+	"github.com/samuel-prates/farm-project/backend/pkg/mergepatch"
+)
+
+// readMergePatch reads the JSON merge patch in the request body. It accepts the
+// application/merge-patch+json media type and, for convenience, plain application/json.
+// On failure the problem response has already been written and ok is false.
+func readMergePatch(w http.ResponseWriter, r *http.Request) (patch []byte, ok bool) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != mergepatch.ContentType && mediaType != "application/json") {
+			logger.Warn("Content-Type não suportado no PATCH: %s", contentType)
+			w.Header().Set("Accept-Patch", mergepatch.ContentType)
+			writeProblem(w, r, http.StatusUnsupportedMediaType, "use "+mergepatch.ContentType+" para atualizações parciais")
+			return nil, false
+		}
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Warn("Erro ao ler o corpo do PATCH: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao ler o corpo da requisição: "+err.Error())
+		return nil, false
+	}
+
+	if _, err := mergepatch.Fields(patch); err != nil {
+		logger.Warn("Erro ao decodificar JSON no PATCH: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return nil, false
+	}
+
+	return patch, true
+}
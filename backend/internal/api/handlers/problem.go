@@ -0,0 +1,61 @@
+// internal/api/handlers/problem.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// problemContentType is the media type defined by RFC 7807
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem document returned for every error response
+type Problem struct {
+	Type     string                  `json:"type"`
+	Title    string                  `json:"title"`
+	Status   int                     `json:"status"`
+	Detail   string                  `json:"detail,omitempty"`
+	Instance string                  `json:"instance,omitempty"`
+	Errors   []validation.FieldError `json:"errors,omitempty"`
+}
+
+// writeProblem sends a problem document with the given status and detail message
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	sendProblem(w, Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+// writeValidationProblem sends a 422 problem document listing every failing field
+func writeValidationProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    "Erro de validação",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   "um ou mais campos são inválidos",
+		Instance: r.URL.Path,
+	}
+
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		problem.Errors = fieldErrors
+	} else {
+		problem.Detail = err.Error()
+	}
+
+	sendProblem(w, problem)
+}
+
+func sendProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,43 @@
+// internal/api/handlers/request_context.go
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+)
+
+// Headers read by RequestContext
+const (
+	RequestIDHeader = "X-Request-ID"
+	ActorHeader     = "X-Actor"
+)
+
+// RequestContext stores the actor and request ID of each request in its context so the
+// services can record them in the audit trail. A request ID is generated when the client
+// does not send one and is echoed back in the response.
+func RequestContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := audit.WithMetadata(r.Context(), audit.Metadata{
+			Actor:     r.Header.Get(ActorHeader),
+			RequestID: requestID,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
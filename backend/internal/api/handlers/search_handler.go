@@ -0,0 +1,36 @@
+// internal/api/handlers/search_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type SearchHandler struct {
+	service SearchServiceInterface
+}
+
+func NewSearchHandler(service SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 0
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	result, err := h.service.Search(query.Get("q"), limit)
+	if err != nil {
+		writeError(w, r, "Erro ao realizar a busca", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,115 @@
+// internal/api/handlers/search_handler_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// MockSearchService is a mock implementation of the SearchServiceInterface
+type MockSearchService struct {
+	SearchFunc func(query string, limit int) (models.SearchResult, error)
+}
+
+func (m *MockSearchService) Search(query string, limit int) (models.SearchResult, error) {
+	return m.SearchFunc(query, limit)
+}
+
+func TestSearchHandler_Search(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		query          string
+		mockSearchFunc func(query string, limit int) (models.SearchResult, error)
+		expectedStatus int
+		expectedQuery  string
+		expectedLimit  int
+	}{
+		{
+			name:  "Success",
+			query: "?q=sao+jose&limit=5",
+			mockSearchFunc: func(query string, limit int) (models.SearchResult, error) {
+				hits := []models.SearchHit{
+					{Type: models.SearchTypeFarm, ID: 3, Name: "Fazenda São José", Highlights: map[string]string{"name": "Fazenda <mark>São</mark> <mark>José</mark>"}},
+				}
+				return models.SearchResult{Query: query, Items: hits}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "sao jose",
+			expectedLimit:  5,
+		},
+		{
+			name:  "Invalid Limit Uses Default",
+			query: "?q=joao&limit=abc",
+			mockSearchFunc: func(query string, limit int) (models.SearchResult, error) {
+				return models.SearchResult{Query: query, Items: []models.SearchHit{}}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "joao",
+			expectedLimit:  0,
+		},
+		{
+			name:  "Missing Query",
+			query: "",
+			mockSearchFunc: func(query string, limit int) (models.SearchResult, error) {
+				return models.SearchResult{}, validation.Errors{{Field: "q", Code: validation.CodeRequired, Message: "informe o termo de busca"}}
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:  "Service Error",
+			query: "?q=joao",
+			mockSearchFunc: func(query string, limit int) (models.SearchResult, error) {
+				return models.SearchResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery string
+			var gotLimit int
+			mockService := &MockSearchService{
+				SearchFunc: func(query string, limit int) (models.SearchResult, error) {
+					gotQuery, gotLimit = query, limit
+					return tt.mockSearchFunc(query, limit)
+				},
+			}
+			handler := NewSearchHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/search"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.Search(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if gotQuery != tt.expectedQuery || gotLimit != tt.expectedLimit {
+				t.Errorf("Service called with (%q, %d), want (%q, %d)", gotQuery, gotLimit, tt.expectedQuery, tt.expectedLimit)
+			}
+
+			var result models.SearchResult
+			if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if result.Query != tt.expectedQuery {
+				t.Errorf("Unexpected query in response: %q", result.Query)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// internal/api/handlers/season_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type SeasonHandler struct {
+	service SeasonServiceInterface
+}
+
+func NewSeasonHandler(service SeasonServiceInterface) *SeasonHandler {
+	return &SeasonHandler{service: service}
+}
+
+func (h *SeasonHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var season models.Season
+	if err := json.NewDecoder(r.Body).Decode(&season); err != nil {
+		logger.Warn("Erro ao decodificar JSON: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	if err := season.Validate(); err != nil {
+		logger.Warn("Erro de validação ao criar temporada: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	createdSeason, err := h.service.Create(r.Context(), &season)
+	if err != nil {
+		writeError(w, r, "Erro ao criar temporada", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(createdSeason)
+}
+
+func (h *SeasonHandler) Update(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao atualizar temporada: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	var season models.Season
+	if err := json.NewDecoder(r.Body).Decode(&season); err != nil {
+		logger.Warn("Erro ao decodificar JSON na atualização de temporada: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+		return
+	}
+
+	season.ID = uint(id)
+	if err := season.Validate(); err != nil {
+		logger.Warn("Erro de validação ao atualizar temporada: %v", err)
+		writeValidationProblem(w, r, err)
+		return
+	}
+
+	updatedSeason, err := h.service.Update(r.Context(), &season)
+	if err != nil {
+		writeError(w, r, "Erro ao atualizar temporada", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updatedSeason)
+}
+
+func (h *SeasonHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao excluir temporada: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		writeError(w, r, "Erro ao excluir temporada", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SeasonHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao buscar temporada: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	season, err := h.service.GetByID(uint(id))
+	if err != nil {
+		writeError(w, r, "Erro ao buscar temporada", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(season)
+}
+
+func (h *SeasonHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetAll(params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar temporadas", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
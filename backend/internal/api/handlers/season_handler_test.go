@@ -0,0 +1,390 @@
+// internal/api/handlers/season_handler_test.go
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+)
+
+// MockSeasonService is a mock implementation of the SeasonServiceInterface
+type MockSeasonService struct {
+	CreateFunc  func(season *models.Season) (*models.Season, error)
+	UpdateFunc  func(season *models.Season) (*models.Season, error)
+	DeleteFunc  func(id uint) error
+	GetByIDFunc func(id uint) (*models.Season, error)
+	GetAllFunc  func(params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+func (m *MockSeasonService) Create(ctx context.Context, season *models.Season) (*models.Season, error) {
+	return m.CreateFunc(season)
+}
+
+func (m *MockSeasonService) Update(ctx context.Context, season *models.Season) (*models.Season, error) {
+	return m.UpdateFunc(season)
+}
+
+func (m *MockSeasonService) Delete(id uint) error {
+	return m.DeleteFunc(id)
+}
+
+func (m *MockSeasonService) GetByID(id uint) (*models.Season, error) {
+	return m.GetByIDFunc(id)
+}
+
+func (m *MockSeasonService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetAllFunc(params)
+}
+
+func validSeasonBody() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      "Safra 2024/25",
+		"cycle":     models.CycleSafra,
+		"startDate": "2024-09-01",
+		"endDate":   "2025-04-30",
+	}
+}
+
+func TestSeasonHandler_Create(t *testing.T) {
+	invalidDates := validSeasonBody()
+	invalidDates["endDate"] = "2024-08-01"
+
+	invalidCycle := validSeasonBody()
+	invalidCycle["cycle"] = "verao"
+
+	// Test cases
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockCreateFunc func(season *models.Season) (*models.Season, error)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			requestBody: validSeasonBody(),
+			mockCreateFunc: func(season *models.Season) (*models.Season, error) {
+				if season.StartDate.Format(models.DateLayout) != "2024-09-01" {
+					return nil, errors.New("start date not decoded")
+				}
+				season.ID = 1
+				return season, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "Invalid JSON",
+			requestBody: "invalid json",
+			mockCreateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "End Before Start",
+			requestBody: invalidDates,
+			mockCreateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Invalid Cycle",
+			requestBody: invalidCycle,
+			mockCreateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "Overlapping Season",
+			requestBody: validSeasonBody(),
+			mockCreateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, apperrors.Conflict("o período se sobrepõe à temporada \"Safra 2024\", do mesmo ciclo")
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:        "Service Error",
+			requestBody: validSeasonBody(),
+			mockCreateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSeasonService{
+				CreateFunc: tt.mockCreateFunc,
+			}
+			handler := NewSeasonHandler(mockService)
+
+			var reqBody []byte
+			var err error
+			if str, ok := tt.requestBody.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, err = json.Marshal(tt.requestBody)
+				if err != nil {
+					t.Fatalf("Failed to marshal request body: %v", err)
+				}
+			}
+
+			req, err := http.NewRequest("POST", "/api/seasons", bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.Create(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Fatalf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			if tt.expectedStatus == http.StatusCreated {
+				var response map[string]interface{}
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("Failed to decode response body: %v", err)
+				}
+				if response["startDate"] != "2024-09-01" || response["endDate"] != "2025-04-30" {
+					t.Errorf("Handler returned unexpected dates: %v", response)
+				}
+			}
+		})
+	}
+}
+
+func TestSeasonHandler_Update(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		seasonID       string
+		mockUpdateFunc func(season *models.Season) (*models.Season, error)
+		expectedStatus int
+	}{
+		{
+			name:     "Success",
+			seasonID: "1",
+			mockUpdateFunc: func(season *models.Season) (*models.Season, error) {
+				if season.ID != 1 {
+					return nil, errors.New("id not assigned")
+				}
+				return season, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "Invalid ID",
+			seasonID: "invalid",
+			mockUpdateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Not Found",
+			seasonID: "999",
+			mockUpdateFunc: func(season *models.Season) (*models.Season, error) {
+				return nil, apperrors.NotFound("temporada não encontrada")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSeasonService{
+				UpdateFunc: tt.mockUpdateFunc,
+			}
+			handler := NewSeasonHandler(mockService)
+
+			reqBody, err := json.Marshal(validSeasonBody())
+			if err != nil {
+				t.Fatalf("Failed to marshal request body: %v", err)
+			}
+
+			req, err := http.NewRequest("PUT", "/api/seasons/"+tt.seasonID, bytes.NewBuffer(reqBody))
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.seasonID})
+
+			rr := httptest.NewRecorder()
+			handler.Update(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestSeasonHandler_Delete(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		seasonID       string
+		mockDeleteFunc func(id uint) error
+		expectedStatus int
+	}{
+		{
+			name:     "Success",
+			seasonID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:     "Invalid ID",
+			seasonID: "invalid",
+			mockDeleteFunc: func(id uint) error {
+				return nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Still Referenced",
+			seasonID: "1",
+			mockDeleteFunc: func(id uint) error {
+				return apperrors.Conflict("registro referenciado não existe ou ainda está em uso")
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSeasonService{
+				DeleteFunc: tt.mockDeleteFunc,
+			}
+			handler := NewSeasonHandler(mockService)
+
+			req, err := http.NewRequest("DELETE", "/api/seasons/"+tt.seasonID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.seasonID})
+
+			rr := httptest.NewRecorder()
+			handler.Delete(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestSeasonHandler_GetByID(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		seasonID        string
+		mockGetByIDFunc func(id uint) (*models.Season, error)
+		expectedStatus  int
+	}{
+		{
+			name:     "Success",
+			seasonID: "1",
+			mockGetByIDFunc: func(id uint) (*models.Season, error) {
+				return &models.Season{ID: id, Name: "Safra 2024/25", Cycle: models.CycleSafra}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "Invalid ID",
+			seasonID: "invalid",
+			mockGetByIDFunc: func(id uint) (*models.Season, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Not Found",
+			seasonID: "999",
+			mockGetByIDFunc: func(id uint) (*models.Season, error) {
+				return nil, apperrors.NotFound("temporada não encontrada")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSeasonService{
+				GetByIDFunc: tt.mockGetByIDFunc,
+			}
+			handler := NewSeasonHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/seasons/"+tt.seasonID, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.seasonID})
+
+			rr := httptest.NewRecorder()
+			handler.GetByID(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestSeasonHandler_GetAll(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		mockGetAllFunc func(params models.PaginationParams) (models.PaginatedResult, error)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				seasons := []models.Season{{ID: 1, Name: "Safra 2024/25", Cycle: models.CycleSafra}}
+				return models.NewPaginatedResult(seasons, 1, params), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Service Error",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockSeasonService{
+				GetAllFunc: tt.mockGetAllFunc,
+			}
+			handler := NewSeasonHandler(mockService)
+
+			req, err := http.NewRequest("GET", "/api/seasons?page=1&limit=10", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.GetAll(rr, req)
+
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
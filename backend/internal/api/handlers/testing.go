@@ -2,7 +2,10 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
 )
 
 // DashboardData represents the data returned by the dashboard
@@ -17,23 +20,122 @@ type AreaDistribution struct {
 	VegetationArea  float64 `json:"vegetationArea"`
 }
 
+// DashboardSummary represents every dashboard widget in a single payload
+type DashboardSummary struct {
+	TotalFarms       int                          `json:"totalFarms"`
+	TotalArea        float64                      `json:"totalArea"`
+	FarmsByState     []models.StateCount          `json:"farmsByState"`
+	HarvestCultures  []models.HarvestCultureCount `json:"harvestCultures"`
+	AreaDistribution AreaDistribution             `json:"areaDistribution"`
+}
+
 // FarmerServiceInterface defines the interface for the FarmerService
 // This is used for testing to allow mocking the service
 type FarmerServiceInterface interface {
-	Create(farmer *models.Farmer) (*models.Farmer, error)
-	Update(farmer *models.Farmer) (*models.Farmer, error)
-	Delete(id uint) error
+	Create(ctx context.Context, farmer *models.Farmer) (*models.Farmer, error)
+	Update(ctx context.Context, farmer *models.Farmer, ifMatch string) (*models.Farmer, error)
+	Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farmer, error)
+	Delete(ctx context.Context, id uint, ifMatch string) error
 	GetByID(id uint) (*models.Farmer, error)
+	GetAll(filter models.FarmerFilter, params models.PaginationParams) (models.PaginatedResult, error)
+	GetPage(filter models.FarmerFilter, params models.CursorParams) (models.CursorResult, error)
+}
+
+// FarmServiceInterface defines the interface for the FarmService
+// This is used for testing to allow mocking the service
+type FarmServiceInterface interface {
+	Create(ctx context.Context, farm *models.Farm) (*models.Farm, error)
+	Update(ctx context.Context, farm *models.Farm, ifMatch string) (*models.Farm, error)
+	Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farm, error)
+	Delete(ctx context.Context, id uint, ifMatch string) error
+	GetByID(id uint) (*models.Farm, error)
+	GetAll(params models.PaginationParams) (models.PaginatedResult, error)
+	GetPage(params models.CursorParams) (models.CursorResult, error)
+	GetAllByFarmer(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error)
+	GetNearby(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+// HarvestServiceInterface defines the interface for the HarvestService
+// This is used for testing to allow mocking the service
+type HarvestServiceInterface interface {
+	Create(ctx context.Context, harvest *models.Harvest) (*models.Harvest, error)
+	Update(ctx context.Context, harvest *models.Harvest, ifMatch string) (*models.Harvest, error)
+	Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Harvest, error)
+	Delete(ctx context.Context, id uint, ifMatch string) error
+	GetByID(id uint) (*models.Harvest, error)
+	GetAllByFarm(farmID uint, params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+// SeasonServiceInterface defines the interface for the SeasonService
+// This is used for testing to allow mocking the service
+type SeasonServiceInterface interface {
+	Create(ctx context.Context, season *models.Season) (*models.Season, error)
+	Update(ctx context.Context, season *models.Season) (*models.Season, error)
+	Delete(id uint) error
+	GetByID(id uint) (*models.Season, error)
+	GetAll(params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+// CultureServiceInterface defines the interface for the CultureService
+// This is used for testing to allow mocking the service
+type CultureServiceInterface interface {
+	Create(culture *models.Culture) (*models.Culture, error)
+	Update(culture *models.Culture) (*models.Culture, error)
+	Delete(id uint) error
+	GetByID(id uint) (*models.Culture, error)
 	GetAll(params models.PaginationParams) (models.PaginatedResult, error)
 }
 
+// TrashServiceInterface defines the interface for the TrashService
+// This is used for testing to allow mocking the service
+type TrashServiceInterface interface {
+	GetAll(params models.PaginationParams) (models.PaginatedResult, error)
+	RestoreFarmer(ctx context.Context, id uint) (*models.Farmer, error)
+}
+
+// AuditServiceInterface defines the interface for the AuditService
+// This is used for testing to allow mocking the service
+type AuditServiceInterface interface {
+	GetHistory(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error)
+}
+
+// SearchServiceInterface defines the interface for the SearchService
+// This is used for testing to allow mocking the service
+type SearchServiceInterface interface {
+	Search(query string, limit int) (models.SearchResult, error)
+}
+
+// MapServiceInterface defines the interface for the MapService
+// This is used for testing to allow mocking the service
+type MapServiceInterface interface {
+	GetFarms(filter models.MapFilter) (*geo.FeatureCollection, error)
+}
+
+// BoundaryServiceInterface defines the interface for the BoundaryService
+// This is used for testing to allow mocking the service
+type BoundaryServiceInterface interface {
+	Import(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error)
+	ExportFarm(id uint, format string) (*models.BoundaryFile, error)
+	Export(filter models.MapFilter, format string) (*models.BoundaryFile, error)
+}
+
+// OverlapServiceInterface defines the interface for the OverlapService
+// This is used for testing to allow mocking the service
+type OverlapServiceInterface interface {
+	GetByFarm(id uint) ([]models.FarmOverlap, error)
+	Report() ([]models.FarmOverlapPair, error)
+}
+
 // DashboardServiceInterface defines the interface for the DashboardService
 // This is used for testing to allow mocking the service
 type DashboardServiceInterface interface {
-	GetDashboardData() (*DashboardData, error)
-	GetFarmsByState() ([]models.StateCount, error)
-	GetHarvestTypes() ([]models.HarvestCultureCount, error)
-	GetAreaDistribution() (*AreaDistribution, error)
+	GetDashboardData(filter models.DashboardFilter) (*DashboardData, error)
+	GetFarmsByState(filter models.DashboardFilter) ([]models.StateCount, error)
+	GetHarvestTypes(filter models.DashboardFilter, byYear bool) ([]models.HarvestCultureCount, error)
+	GetAreaDistribution(filter models.DashboardFilter) (*AreaDistribution, error)
+	GetSummary(ctx context.Context, filter models.DashboardFilter) (*DashboardSummary, error)
+	GetProductionByCulture(filter models.DashboardFilter) ([]models.CultureProduction, error)
+	GetProductionBySeason(filter models.DashboardFilter) ([]models.SeasonProduction, error)
 }
 
 // Note: In a real project, we would ensure that the real services implement these interfaces.
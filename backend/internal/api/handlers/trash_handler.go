@@ -0,0 +1,50 @@
+// internal/api/handlers/trash_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type TrashHandler struct {
+	service TrashServiceInterface
+}
+
+func NewTrashHandler(service TrashServiceInterface) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+func (h *TrashHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	params := parsePaginationParams(r)
+
+	result, err := h.service.GetAll(params)
+	if err != nil {
+		writeError(w, r, "Erro ao buscar itens da lixeira", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *TrashHandler) RestoreFarmer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		logger.Warn("ID inválido ao restaurar fazendeiro: %v", err)
+		writeProblem(w, r, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	farmer, err := h.service.RestoreFarmer(r.Context(), uint(id))
+	if err != nil {
+		writeError(w, r, "Erro ao restaurar fazendeiro", err)
+		return
+	}
+
+	writeTaggedEntity(w, r, http.StatusOK, farmer)
+}
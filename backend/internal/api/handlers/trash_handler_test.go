@@ -0,0 +1,172 @@
+// internal/api/handlers/trash_handler_test.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+)
+
+// MockTrashService is a mock implementation of the TrashServiceInterface
+type MockTrashService struct {
+	GetAllFunc        func(params models.PaginationParams) (models.PaginatedResult, error)
+	RestoreFarmerFunc func(id uint) (*models.Farmer, error)
+}
+
+func (m *MockTrashService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return m.GetAllFunc(params)
+}
+
+func (m *MockTrashService) RestoreFarmer(ctx context.Context, id uint) (*models.Farmer, error) {
+	return m.RestoreFarmerFunc(id)
+}
+
+func TestTrashHandler_GetAll(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name           string
+		query          string
+		mockGetAllFunc func(params models.PaginationParams) (models.PaginatedResult, error)
+		expectedStatus int
+		expectedTotal  int64
+	}{
+		{
+			name:  "Success",
+			query: "?page=1&limit=10",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				items := []models.TrashItem{
+					{Type: models.TrashTypeFarmer, ID: 1, Name: "João", DeletedAt: time.Now()},
+					{Type: models.TrashTypeFarm, ID: 2, Name: "Fazenda Boa Vista", DeletedAt: time.Now()},
+				}
+				return models.NewPaginatedResult(items, 2, params), nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedTotal:  2,
+		},
+		{
+			name:  "Service Error",
+			query: "",
+			mockGetAllFunc: func(params models.PaginationParams) (models.PaginatedResult, error) {
+				return models.PaginatedResult{}, errors.New("service error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockTrashService{
+				GetAllFunc: tt.mockGetAllFunc,
+			}
+			handler := NewTrashHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("GET", "/api/trash"+tt.query, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.GetAll(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+
+			// For successful requests, check the response body
+			if tt.expectedStatus == http.StatusOK {
+				var result models.PaginatedResult
+				if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if result.Total != tt.expectedTotal {
+					t.Errorf("Handler returned wrong total: got %v want %v", result.Total, tt.expectedTotal)
+				}
+			}
+		})
+	}
+}
+
+func TestTrashHandler_RestoreFarmer(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name                  string
+		farmerID              string
+		mockRestoreFarmerFunc func(id uint) (*models.Farmer, error)
+		expectedStatus        int
+	}{
+		{
+			name:     "Success",
+			farmerID: "1",
+			mockRestoreFarmerFunc: func(id uint) (*models.Farmer, error) {
+				return &models.Farmer{ID: id, FarmerName: "João", FederalIdentification: "52998224725"}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "Invalid ID",
+			farmerID: "invalid",
+			mockRestoreFarmerFunc: func(id uint) (*models.Farmer, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Not In Trash",
+			farmerID: "999",
+			mockRestoreFarmerFunc: func(id uint) (*models.Farmer, error) {
+				return nil, apperrors.NotFound("fazendeiro não encontrado")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:     "Document Reused",
+			farmerID: "1",
+			mockRestoreFarmerFunc: func(id uint) (*models.Farmer, error) {
+				return nil, apperrors.Conflict("já existe um fazendeiro com este documento")
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup mock service
+			mockService := &MockTrashService{
+				RestoreFarmerFunc: tt.mockRestoreFarmerFunc,
+			}
+			handler := NewTrashHandler(mockService)
+
+			// Create request
+			req, err := http.NewRequest("POST", "/api/farmers/"+tt.farmerID+"/restore", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			req = mux.SetURLVars(req, map[string]string{"id": tt.farmerID})
+
+			// Create response recorder
+			rr := httptest.NewRecorder()
+
+			// Call the handler
+			handler.RestoreFarmer(rr, req)
+
+			// Check status code
+			if status := rr.Code; status != tt.expectedStatus {
+				t.Errorf("Handler returned wrong status code: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
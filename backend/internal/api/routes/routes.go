@@ -11,28 +11,99 @@ import (
 
 func SetupRoutes(
 	farmerHandler *routeHandlers.FarmerHandler,
+	farmHandler *routeHandlers.FarmHandler,
+	harvestHandler *routeHandlers.HarvestHandler,
+	trashHandler *routeHandlers.TrashHandler,
+	auditHandler *routeHandlers.AuditHandler,
+	searchHandler *routeHandlers.SearchHandler,
+	seasonHandler *routeHandlers.SeasonHandler,
+	cultureHandler *routeHandlers.CultureHandler,
+	mapHandler *routeHandlers.MapHandler,
+	boundaryHandler *routeHandlers.BoundaryHandler,
+	overlapHandler *routeHandlers.OverlapHandler,
 	dashboardHandler *routeHandlers.DashboardHandler,
 ) http.Handler {
 	r := mux.NewRouter()
+	r.Use(routeHandlers.RequestContext)
 
 	// Rotas para Fazendeiros
 	r.HandleFunc("/api/farmers", farmerHandler.Create).Methods("POST")
 	r.HandleFunc("/api/farmers/{id}", farmerHandler.Update).Methods("PUT")
+	r.HandleFunc("/api/farmers/{id}", farmerHandler.Patch).Methods("PATCH")
 	r.HandleFunc("/api/farmers/{id}", farmerHandler.Delete).Methods("DELETE")
 	r.HandleFunc("/api/farmers/{id}", farmerHandler.GetByID).Methods("GET")
 	r.HandleFunc("/api/farmers", farmerHandler.GetAll).Methods("GET")
+	r.HandleFunc("/api/farmers/{id}/farms", farmHandler.GetByFarmer).Methods("GET")
+	r.HandleFunc("/api/farmers/{id}/farms", farmHandler.CreateForFarmer).Methods("POST")
+	r.HandleFunc("/api/farmers/{id}/restore", trashHandler.RestoreFarmer).Methods("POST")
+	r.HandleFunc("/api/farmers/{id}/history", auditHandler.GetFarmerHistory).Methods("GET")
+
+	// Rotas para Fazendas
+	r.HandleFunc("/api/farms", farmHandler.Create).Methods("POST")
+	// Registradas antes de /api/farms/{id}, que também casaria com "overlaps" e "nearby"
+	r.HandleFunc("/api/farms/overlaps", overlapHandler.Report).Methods("GET")
+	r.HandleFunc("/api/farms/nearby", farmHandler.GetNearby).Methods("GET")
+	r.HandleFunc("/api/farms/{id}", farmHandler.Update).Methods("PUT")
+	r.HandleFunc("/api/farms/{id}", farmHandler.Patch).Methods("PATCH")
+	r.HandleFunc("/api/farms/{id}", farmHandler.Delete).Methods("DELETE")
+	r.HandleFunc("/api/farms/{id}", farmHandler.GetByID).Methods("GET")
+	r.HandleFunc("/api/farms", farmHandler.GetAll).Methods("GET")
+	r.HandleFunc("/api/farms/{farmId}/harvests", harvestHandler.GetByFarm).Methods("GET")
+	r.HandleFunc("/api/farms/{farmId}/harvests", harvestHandler.CreateForFarm).Methods("POST")
+	r.HandleFunc("/api/farms/{id}/history", auditHandler.GetFarmHistory).Methods("GET")
+	r.HandleFunc("/api/farms/{id}/boundary", boundaryHandler.Import).Methods("PUT")
+	r.HandleFunc("/api/farms/{id}/boundary", boundaryHandler.ExportFarm).Methods("GET")
+	r.HandleFunc("/api/farms/{id}/overlaps", overlapHandler.GetByFarm).Methods("GET")
+
+	// Rotas para Safras
+	r.HandleFunc("/api/harvests/{id}", harvestHandler.Update).Methods("PUT")
+	r.HandleFunc("/api/harvests/{id}", harvestHandler.Patch).Methods("PATCH")
+	r.HandleFunc("/api/harvests/{id}", harvestHandler.Delete).Methods("DELETE")
+	r.HandleFunc("/api/harvests/{id}", harvestHandler.GetByID).Methods("GET")
+
+	// Rotas para Temporadas
+	r.HandleFunc("/api/seasons", seasonHandler.Create).Methods("POST")
+	r.HandleFunc("/api/seasons/{id}", seasonHandler.Update).Methods("PUT")
+	r.HandleFunc("/api/seasons/{id}", seasonHandler.Delete).Methods("DELETE")
+	r.HandleFunc("/api/seasons/{id}", seasonHandler.GetByID).Methods("GET")
+	r.HandleFunc("/api/seasons", seasonHandler.GetAll).Methods("GET")
+
+	// Rotas para Culturas
+	r.HandleFunc("/api/cultures", cultureHandler.Create).Methods("POST")
+	r.HandleFunc("/api/cultures/{id}", cultureHandler.Update).Methods("PUT")
+	r.HandleFunc("/api/cultures/{id}", cultureHandler.Delete).Methods("DELETE")
+	r.HandleFunc("/api/cultures/{id}", cultureHandler.GetByID).Methods("GET")
+	r.HandleFunc("/api/cultures", cultureHandler.GetAll).Methods("GET")
+
+	// Rotas para Lixeira
+	r.HandleFunc("/api/trash", trashHandler.GetAll).Methods("GET")
+
+	// Rotas para Busca
+	r.HandleFunc("/api/search", searchHandler.Search).Methods("GET")
+
+	// Rotas para Mapa
+	r.HandleFunc("/api/map/farms", mapHandler.GetFarms).Methods("GET")
+	r.HandleFunc("/api/map/farms/export", boundaryHandler.Export).Methods("GET")
 
 	// Rotas para Dashboard
 	r.HandleFunc("/api/dashboard", dashboardHandler.GetDashboardData).Methods("GET")
 	r.HandleFunc("/api/dashboard/farm-states", dashboardHandler.GetFarmsByState).Methods("GET")
 	r.HandleFunc("/api/dashboard/harvest-cultures", dashboardHandler.GetHarvestTypes).Methods("GET")
 	r.HandleFunc("/api/dashboard/areas", dashboardHandler.GetAreaDistribution).Methods("GET")
+	r.HandleFunc("/api/dashboard/summary", dashboardHandler.GetSummary).Methods("GET")
+	r.HandleFunc("/api/dashboard/production", dashboardHandler.GetProductionByCulture).Methods("GET")
+	r.HandleFunc("/api/dashboard/production/seasons", dashboardHandler.GetProductionBySeason).Methods("GET")
 
 	// Add CORS middleware
 	corsMiddleware := handlers.CORS(
 		handlers.AllowedOrigins([]string{"*", "http://localhost:*"}),
-		handlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-		handlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+		handlers.AllowedMethods([]string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+		handlers.AllowedHeaders([]string{
+			"Content-Type", "Authorization",
+			routeHandlers.RequestIDHeader, routeHandlers.ActorHeader,
+			routeHandlers.IfMatchHeader, routeHandlers.IfNoneMatchHeader,
+		}),
+		handlers.ExposedHeaders([]string{routeHandlers.RequestIDHeader, routeHandlers.ETagHeader, "Content-Disposition"}),
 	)
 
 	return corsMiddleware(r)
@@ -2,25 +2,34 @@
 package routes
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gorilla/mux"
 	"github.com/samuel-prates/farm-project/backend/internal/api/handlers"
 	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
 )
 
 // MockFarmerService is a mock implementation of the FarmerServiceInterface
 type MockFarmerService struct{}
 
-func (m *MockFarmerService) Create(farmer *models.Farmer) (*models.Farmer, error) {
+func (m *MockFarmerService) Create(ctx context.Context, farmer *models.Farmer) (*models.Farmer, error) {
 	return farmer, nil
 }
 
-func (m *MockFarmerService) Update(farmer *models.Farmer) (*models.Farmer, error) {
+func (m *MockFarmerService) Update(ctx context.Context, farmer *models.Farmer, ifMatch string) (*models.Farmer, error) {
 	return farmer, nil
 }
 
-func (m *MockFarmerService) Delete(id uint) error {
+func (m *MockFarmerService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farmer, error) {
+	return &models.Farmer{ID: id}, nil
+}
+
+func (m *MockFarmerService) Delete(ctx context.Context, id uint, ifMatch string) error {
 	return nil
 }
 
@@ -28,29 +37,215 @@ func (m *MockFarmerService) GetByID(id uint) (*models.Farmer, error) {
 	return &models.Farmer{ID: id}, nil
 }
 
-func (m *MockFarmerService) GetAll() ([]models.Farmer, error) {
-	return []models.Farmer{}, nil
+func (m *MockFarmerService) GetAll(filter models.FarmerFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.Farmer{}, 0, params), nil
+}
+
+func (m *MockFarmerService) GetPage(filter models.FarmerFilter, params models.CursorParams) (models.CursorResult, error) {
+	return models.CursorResult{Items: []models.Farmer{}, Limit: params.Limit}, nil
+}
+
+// MockFarmService is a mock implementation of the FarmServiceInterface
+type MockFarmService struct{}
+
+func (m *MockFarmService) Create(ctx context.Context, farm *models.Farm) (*models.Farm, error) {
+	return farm, nil
+}
+
+func (m *MockFarmService) Update(ctx context.Context, farm *models.Farm, ifMatch string) (*models.Farm, error) {
+	return farm, nil
+}
+
+func (m *MockFarmService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farm, error) {
+	return &models.Farm{ID: id}, nil
+}
+
+func (m *MockFarmService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return nil
+}
+
+func (m *MockFarmService) GetByID(id uint) (*models.Farm, error) {
+	return &models.Farm{ID: id}, nil
+}
+
+func (m *MockFarmService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.Farm{}, 0, params), nil
+}
+
+func (m *MockFarmService) GetPage(params models.CursorParams) (models.CursorResult, error) {
+	return models.CursorResult{Items: []models.Farm{}, Limit: params.Limit}, nil
+}
+
+func (m *MockFarmService) GetAllByFarmer(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.Farm{}, 0, params), nil
+}
+
+func (m *MockFarmService) GetNearby(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.NearbyFarm{}, 0, params), nil
+}
+
+// MockHarvestService is a mock implementation of the HarvestServiceInterface
+type MockHarvestService struct{}
+
+func (m *MockHarvestService) Create(ctx context.Context, harvest *models.Harvest) (*models.Harvest, error) {
+	return harvest, nil
+}
+
+func (m *MockHarvestService) Update(ctx context.Context, harvest *models.Harvest, ifMatch string) (*models.Harvest, error) {
+	return harvest, nil
+}
+
+func (m *MockHarvestService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Harvest, error) {
+	return &models.Harvest{ID: id}, nil
+}
+
+func (m *MockHarvestService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return nil
+}
+
+func (m *MockHarvestService) GetByID(id uint) (*models.Harvest, error) {
+	return &models.Harvest{ID: id}, nil
+}
+
+func (m *MockHarvestService) GetAllByFarm(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.Harvest{}, 0, params), nil
+}
+
+// MockTrashService is a mock implementation of the TrashServiceInterface
+type MockTrashService struct{}
+
+func (m *MockTrashService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.TrashItem{}, 0, params), nil
+}
+
+func (m *MockTrashService) RestoreFarmer(ctx context.Context, id uint) (*models.Farmer, error) {
+	return &models.Farmer{ID: id}, nil
+}
+
+// MockSearchService is a mock implementation of the SearchServiceInterface
+type MockSearchService struct{}
+
+func (m *MockSearchService) Search(query string, limit int) (models.SearchResult, error) {
+	return models.SearchResult{Query: query, Items: []models.SearchHit{}}, nil
+}
+
+// MockSeasonService is a mock implementation of the SeasonServiceInterface
+type MockSeasonService struct{}
+
+func (m *MockSeasonService) Create(ctx context.Context, season *models.Season) (*models.Season, error) {
+	return season, nil
+}
+
+func (m *MockSeasonService) Update(ctx context.Context, season *models.Season) (*models.Season, error) {
+	return season, nil
+}
+
+func (m *MockSeasonService) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockSeasonService) GetByID(id uint) (*models.Season, error) {
+	return &models.Season{ID: id}, nil
+}
+
+func (m *MockSeasonService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.Season{}, 0, params), nil
+}
+
+// MockCultureService is a mock implementation of the CultureServiceInterface
+type MockCultureService struct{}
+
+func (m *MockCultureService) Create(culture *models.Culture) (*models.Culture, error) {
+	return culture, nil
+}
+
+func (m *MockCultureService) Update(culture *models.Culture) (*models.Culture, error) {
+	return culture, nil
+}
+
+func (m *MockCultureService) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockCultureService) GetByID(id uint) (*models.Culture, error) {
+	return &models.Culture{ID: id}, nil
+}
+
+func (m *MockCultureService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.Culture{}, 0, params), nil
+}
+
+// MockAuditService is a mock implementation of the AuditServiceInterface
+type MockAuditService struct{}
+
+func (m *MockAuditService) GetHistory(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	return models.NewPaginatedResult([]models.AuditEvent{}, 0, params), nil
+}
+
+// MockMapService is a mock implementation of the MapServiceInterface
+type MockMapService struct{}
+
+func (m *MockMapService) GetFarms(filter models.MapFilter) (*geo.FeatureCollection, error) {
+	return &geo.FeatureCollection{}, nil
+}
+
+// MockBoundaryService is a mock implementation of the BoundaryServiceInterface
+type MockBoundaryService struct{}
+
+func (m *MockBoundaryService) Import(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+	return &models.Farm{ID: farmID}, nil
+}
+
+func (m *MockBoundaryService) ExportFarm(id uint, format string) (*models.BoundaryFile, error) {
+	return &models.BoundaryFile{}, nil
+}
+
+func (m *MockBoundaryService) Export(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+	return &models.BoundaryFile{}, nil
+}
+
+// MockOverlapService is a mock implementation of the OverlapServiceInterface
+type MockOverlapService struct{}
+
+func (m *MockOverlapService) GetByFarm(id uint) ([]models.FarmOverlap, error) {
+	return []models.FarmOverlap{}, nil
+}
+
+func (m *MockOverlapService) Report() ([]models.FarmOverlapPair, error) {
+	return []models.FarmOverlapPair{{FarmID: 1, OtherFarmID: 2, Area: 10}}, nil
 }
 
 // MockDashboardService is a mock implementation of the DashboardServiceInterface
 type MockDashboardService struct{}
 
-func (m *MockDashboardService) GetDashboardData() (*handlers.DashboardData, error) {
+func (m *MockDashboardService) GetDashboardData(filter models.DashboardFilter) (*handlers.DashboardData, error) {
 	return &handlers.DashboardData{}, nil
 }
 
-func (m *MockDashboardService) GetFarmsByState() ([]models.StateCount, error) {
+func (m *MockDashboardService) GetFarmsByState(filter models.DashboardFilter) ([]models.StateCount, error) {
 	return []models.StateCount{}, nil
 }
 
-func (m *MockDashboardService) GetHarvestTypes() ([]models.HarvestCultureCount, error) {
+func (m *MockDashboardService) GetHarvestTypes(filter models.DashboardFilter, byYear bool) ([]models.HarvestCultureCount, error) {
 	return []models.HarvestCultureCount{}, nil
 }
 
-func (m *MockDashboardService) GetAreaDistribution() (*handlers.AreaDistribution, error) {
+func (m *MockDashboardService) GetAreaDistribution(filter models.DashboardFilter) (*handlers.AreaDistribution, error) {
 	return &handlers.AreaDistribution{}, nil
 }
 
+func (m *MockDashboardService) GetSummary(ctx context.Context, filter models.DashboardFilter) (*handlers.DashboardSummary, error) {
+	return &handlers.DashboardSummary{}, nil
+}
+
+func (m *MockDashboardService) GetProductionByCulture(filter models.DashboardFilter) ([]models.CultureProduction, error) {
+	return []models.CultureProduction{}, nil
+}
+
+func (m *MockDashboardService) GetProductionBySeason(filter models.DashboardFilter) ([]models.SeasonProduction, error) {
+	return []models.SeasonProduction{}, nil
+}
+
 // Helper function to find a route by path and method
 func findRoute(router *mux.Router, path string, method string) bool {
 	var found bool
@@ -82,14 +277,34 @@ func findRoute(router *mux.Router, path string, method string) bool {
 func TestSetupRoutes(t *testing.T) {
 	// Create mock services
 	mockFarmerService := &MockFarmerService{}
+	mockFarmService := &MockFarmService{}
+	mockHarvestService := &MockHarvestService{}
+	mockTrashService := &MockTrashService{}
+	mockAuditService := &MockAuditService{}
+	mockSearchService := &MockSearchService{}
+	mockSeasonService := &MockSeasonService{}
+	mockCultureService := &MockCultureService{}
+	mockMapService := &MockMapService{}
+	mockBoundaryService := &MockBoundaryService{}
+	mockOverlapService := &MockOverlapService{}
 	mockDashboardService := &MockDashboardService{}
 
 	// Create handlers with mock services
 	mockFarmerHandler := handlers.NewFarmerHandler(mockFarmerService)
+	mockFarmHandler := handlers.NewFarmHandler(mockFarmService)
+	mockHarvestHandler := handlers.NewHarvestHandler(mockHarvestService)
+	mockTrashHandler := handlers.NewTrashHandler(mockTrashService)
+	mockAuditHandler := handlers.NewAuditHandler(mockAuditService)
+	mockSearchHandler := handlers.NewSearchHandler(mockSearchService)
+	mockSeasonHandler := handlers.NewSeasonHandler(mockSeasonService)
+	mockCultureHandler := handlers.NewCultureHandler(mockCultureService)
+	mockMapHandler := handlers.NewMapHandler(mockMapService)
+	mockBoundaryHandler := handlers.NewBoundaryHandler(mockBoundaryService)
+	mockOverlapHandler := handlers.NewOverlapHandler(mockOverlapService)
 	mockDashboardHandler := handlers.NewDashboardHandler(mockDashboardService)
 
 	// Setup routes
-	handler := SetupRoutes(mockFarmerHandler, mockDashboardHandler)
+	handler := SetupRoutes(mockFarmerHandler, mockFarmHandler, mockHarvestHandler, mockTrashHandler, mockAuditHandler, mockSearchHandler, mockSeasonHandler, mockCultureHandler, mockMapHandler, mockBoundaryHandler, mockOverlapHandler, mockDashboardHandler)
 
 	// Extract the router from the handler (which is wrapped with CORS middleware)
 	router, ok := handler.(*mux.Router)
@@ -108,15 +323,69 @@ func TestSetupRoutes(t *testing.T) {
 		// Farmer routes
 		{"Create Farmer", "/api/farmers", "POST"},
 		{"Update Farmer", "/api/farmers/{id}", "PUT"},
+		{"Patch Farmer", "/api/farmers/{id}", "PATCH"},
 		{"Delete Farmer", "/api/farmers/{id}", "DELETE"},
 		{"Get Farmer by ID", "/api/farmers/{id}", "GET"},
 		{"Get All Farmers", "/api/farmers", "GET"},
+		{"Get Farms by Farmer", "/api/farmers/{id}/farms", "GET"},
+		{"Create Farm for Farmer", "/api/farmers/{id}/farms", "POST"},
+		{"Restore Farmer", "/api/farmers/{id}/restore", "POST"},
+		{"Get Farmer History", "/api/farmers/{id}/history", "GET"},
+
+		// Farm routes
+		{"Create Farm", "/api/farms", "POST"},
+		{"Update Farm", "/api/farms/{id}", "PUT"},
+		{"Patch Farm", "/api/farms/{id}", "PATCH"},
+		{"Delete Farm", "/api/farms/{id}", "DELETE"},
+		{"Get Farm by ID", "/api/farms/{id}", "GET"},
+		{"Get All Farms", "/api/farms", "GET"},
+		{"Get Harvests by Farm", "/api/farms/{farmId}/harvests", "GET"},
+		{"Create Harvest for Farm", "/api/farms/{farmId}/harvests", "POST"},
+		{"Get Farm History", "/api/farms/{id}/history", "GET"},
+		{"Import Farm Boundary", "/api/farms/{id}/boundary", "PUT"},
+		{"Export Farm Boundary", "/api/farms/{id}/boundary", "GET"},
+		{"Get Farm Overlaps", "/api/farms/{id}/overlaps", "GET"},
+		{"Get Overlap Report", "/api/farms/overlaps", "GET"},
+		{"Get Nearby Farms", "/api/farms/nearby", "GET"},
+
+		// Harvest routes
+		{"Update Harvest", "/api/harvests/{id}", "PUT"},
+		{"Patch Harvest", "/api/harvests/{id}", "PATCH"},
+		{"Delete Harvest", "/api/harvests/{id}", "DELETE"},
+		{"Get Harvest by ID", "/api/harvests/{id}", "GET"},
+
+		// Season routes
+		{"Create Season", "/api/seasons", "POST"},
+		{"Update Season", "/api/seasons/{id}", "PUT"},
+		{"Delete Season", "/api/seasons/{id}", "DELETE"},
+		{"Get Season by ID", "/api/seasons/{id}", "GET"},
+		{"Get All Seasons", "/api/seasons", "GET"},
+
+		// Culture routes
+		{"Create Culture", "/api/cultures", "POST"},
+		{"Update Culture", "/api/cultures/{id}", "PUT"},
+		{"Delete Culture", "/api/cultures/{id}", "DELETE"},
+		{"Get Culture by ID", "/api/cultures/{id}", "GET"},
+		{"Get All Cultures", "/api/cultures", "GET"},
+
+		// Trash routes
+		{"Get Trash", "/api/trash", "GET"},
+
+		// Search routes
+		{"Search", "/api/search", "GET"},
+
+		// Map routes
+		{"Get Farms Map", "/api/map/farms", "GET"},
+		{"Export Farm Boundaries", "/api/map/farms/export", "GET"},
 
 		// Dashboard routes
 		{"Get Dashboard Data", "/api/dashboard", "GET"},
 		{"Get Farms by State", "/api/dashboard/farms-by-state", "GET"},
 		{"Get Harvest Types", "/api/dashboard/harvest-types", "GET"},
 		{"Get Area Distribution", "/api/dashboard/area-distribution", "GET"},
+		{"Get Dashboard Summary", "/api/dashboard/summary", "GET"},
+		{"Get Production by Culture", "/api/dashboard/production", "GET"},
+		{"Get Production by Season", "/api/dashboard/production/seasons", "GET"},
 	}
 
 	// Check each route
@@ -133,16 +402,78 @@ func TestSetupRoutes(t *testing.T) {
 func TestRouteHandlers(t *testing.T) {
 	// Create mock services
 	mockFarmerService := &MockFarmerService{}
+	mockFarmService := &MockFarmService{}
+	mockHarvestService := &MockHarvestService{}
+	mockTrashService := &MockTrashService{}
+	mockAuditService := &MockAuditService{}
+	mockSearchService := &MockSearchService{}
+	mockSeasonService := &MockSeasonService{}
+	mockCultureService := &MockCultureService{}
+	mockMapService := &MockMapService{}
+	mockBoundaryService := &MockBoundaryService{}
+	mockOverlapService := &MockOverlapService{}
 	mockDashboardService := &MockDashboardService{}
 
 	// Create handlers with mock services
 	mockFarmerHandler := handlers.NewFarmerHandler(mockFarmerService)
+	mockFarmHandler := handlers.NewFarmHandler(mockFarmService)
+	mockHarvestHandler := handlers.NewHarvestHandler(mockHarvestService)
+	mockTrashHandler := handlers.NewTrashHandler(mockTrashService)
+	mockAuditHandler := handlers.NewAuditHandler(mockAuditService)
+	mockSearchHandler := handlers.NewSearchHandler(mockSearchService)
+	mockSeasonHandler := handlers.NewSeasonHandler(mockSeasonService)
+	mockCultureHandler := handlers.NewCultureHandler(mockCultureService)
+	mockMapHandler := handlers.NewMapHandler(mockMapService)
+	mockBoundaryHandler := handlers.NewBoundaryHandler(mockBoundaryService)
+	mockOverlapHandler := handlers.NewOverlapHandler(mockOverlapService)
 	mockDashboardHandler := handlers.NewDashboardHandler(mockDashboardService)
 
 	// Setup routes
-	SetupRoutes(mockFarmerHandler, mockDashboardHandler)
+	SetupRoutes(mockFarmerHandler, mockFarmHandler, mockHarvestHandler, mockTrashHandler, mockAuditHandler, mockSearchHandler, mockSeasonHandler, mockCultureHandler, mockMapHandler, mockBoundaryHandler, mockOverlapHandler, mockDashboardHandler)
 
 	// This test simply verifies that the SetupRoutes function doesn't panic
 	// In a real test, we would make actual HTTP requests to each endpoint
 	// and verify the responses, but that would require a running server
 }
+
+// The static farm routes share their prefix with /api/farms/{id}, which would reject
+// them as an invalid ID if it were matched first
+func TestStaticFarmRoutes(t *testing.T) {
+	handler := SetupRoutes(
+		handlers.NewFarmerHandler(&MockFarmerService{}),
+		handlers.NewFarmHandler(&MockFarmService{}),
+		handlers.NewHarvestHandler(&MockHarvestService{}),
+		handlers.NewTrashHandler(&MockTrashService{}),
+		handlers.NewAuditHandler(&MockAuditService{}),
+		handlers.NewSearchHandler(&MockSearchService{}),
+		handlers.NewSeasonHandler(&MockSeasonService{}),
+		handlers.NewCultureHandler(&MockCultureService{}),
+		handlers.NewMapHandler(&MockMapService{}),
+		handlers.NewBoundaryHandler(&MockBoundaryService{}),
+		handlers.NewOverlapHandler(&MockOverlapService{}),
+		handlers.NewDashboardHandler(&MockDashboardService{}),
+	)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"Overlap Report", "/api/farms/overlaps"},
+		{"Nearby Farms", "/api/farms/nearby?lat=-12.55&lon=-55.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+			}
+			if !json.Valid(rr.Body.Bytes()) {
+				t.Errorf("Expected a JSON body, got %s", rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// internal/models/audit_event.go
+package models
+
+import "time"
+
+// Entity types recorded in the audit trail
+const (
+	AuditEntityFarmer  = "farmer"
+	AuditEntityFarm    = "farm"
+	AuditEntityHarvest = "harvest"
+)
+
+// AuditEvent records one create, update, delete or restore of an entity. Parent points
+// to the owning entity (the farmer of a farm, the farm of a harvest) so that the history
+// of a record also lists the changes made to its children.
+type AuditEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;index:idx_audit_entity"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;index:idx_audit_entity"`
+	ParentType string    `json:"parent_type,omitempty" gorm:"index:idx_audit_parent"`
+	ParentID   *uint     `json:"parent_id,omitempty" gorm:"index:idx_audit_parent"`
+	Action     string    `json:"action" gorm:"not null"`
+	Actor      string    `json:"actor"`
+	RequestID  string    `json:"request_id"`
+	Before     JSON      `json:"before" gorm:"type:jsonb"`
+	After      JSON      `json:"after" gorm:"type:jsonb"`
+	Changes    JSON      `json:"changes" gorm:"type:jsonb"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,116 @@
+// internal/models/culture.go
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/search"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// Categories of the culture catalog. CategoryOutra holds the crops that fit none of the
+// others, including the ones created from legacy harvests.
+const (
+	CategoryGrao  = "grao"
+	CategoryFibra = "fibra"
+	CategoryFruta = "fruta"
+	CategoryOutra = "outra"
+)
+
+// Culture is a crop of the managed catalog. Harvests reference it and keep a copy of
+// its canonical Name, so "Soja", "soja" and "SOJA " count as the same crop.
+type Culture struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	Name           string         `json:"name" gorm:"not null"`
+	NormalizedName string         `json:"-" gorm:"not null;uniqueIndex"`
+	Category       string         `json:"category" gorm:"type:varchar(10);not null"`
+	DefaultUnit    string         `json:"defaultUnit" gorm:"type:varchar(10)"`
+	Aliases        []CultureAlias `json:"aliases" gorm:"foreignKey:CultureID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Harvests       []Harvest      `json:"-" gorm:"foreignKey:CultureID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// CultureAlias is another name a culture is known by, such as "Soybean" for "Soja".
+// It is read and written in JSON as a plain string.
+type CultureAlias struct {
+	ID             uint   `gorm:"primaryKey"`
+	CultureID      uint   `gorm:"not null;index"`
+	Name           string `gorm:"not null"`
+	NormalizedName string `gorm:"not null;uniqueIndex"`
+}
+
+func (a CultureAlias) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Name)
+}
+
+func (a *CultureAlias) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &a.Name)
+}
+
+// NormalizeCultureName returns the key culture names and aliases are matched by: folded
+// case and accents with the whitespace collapsed
+func NormalizeCultureName(name string) string {
+	return search.Fold(strings.Join(strings.Fields(name), " "))
+}
+
+func (c *Culture) Validate() error {
+	var errs validation.Errors
+
+	if strings.TrimSpace(c.Name) == "" {
+		errs.Add("name", validation.CodeRequired, "nome da cultura é obrigatório")
+	}
+
+	switch c.Category {
+	case CategoryGrao, CategoryFibra, CategoryFruta, CategoryOutra:
+	case "":
+		errs.Add("category", validation.CodeRequired, "categoria da cultura é obrigatória")
+	default:
+		errs.Add("category", validation.CodeInvalid, "categoria deve ser grao, fibra, fruta ou outra")
+	}
+
+	switch c.DefaultUnit {
+	case "", UnitSacas, UnitToneladas, UnitKg:
+	default:
+		errs.Add("defaultUnit", validation.CodeInvalid, "unidade deve ser sacas, toneladas ou kg")
+	}
+
+	for i, alias := range c.Aliases {
+		if strings.TrimSpace(alias.Name) == "" {
+			errs.Add(validation.Index("aliases", i), validation.CodeRequired, "apelido não pode ser vazio")
+		}
+	}
+
+	return errs.Err()
+}
+
+// Normalize trims the names and fills in their normalized forms. Aliases that repeat
+// the name or another alias are dropped.
+func (c *Culture) Normalize() {
+	c.Name = strings.Join(strings.Fields(c.Name), " ")
+	c.NormalizedName = NormalizeCultureName(c.Name)
+
+	seen := map[string]bool{c.NormalizedName: true}
+	aliases := make([]CultureAlias, 0, len(c.Aliases))
+	for _, alias := range c.Aliases {
+		alias.Name = strings.Join(strings.Fields(alias.Name), " ")
+		alias.NormalizedName = NormalizeCultureName(alias.Name)
+		if seen[alias.NormalizedName] {
+			continue
+		}
+		seen[alias.NormalizedName] = true
+		aliases = append(aliases, alias)
+	}
+	c.Aliases = aliases
+}
+
+// Names returns the name followed by the aliases
+func (c *Culture) Names() []string {
+	names := []string{c.Name}
+	for _, alias := range c.Aliases {
+		names = append(names, alias.Name)
+	}
+	return names
+}
@@ -0,0 +1,21 @@
+// internal/models/cursor.go
+package models
+
+import "github.com/samuel-prates/farm-project/backend/pkg/cursor"
+
+// CursorParams represents the parameters for keyset pagination
+type CursorParams struct {
+	Cursor    cursor.Cursor
+	Limit     int
+	WithTotal bool
+}
+
+// CursorResult represents a page of a keyset-paginated list. Total is only filled
+// when the client asks for it, since counting defeats the point on large tables.
+type CursorResult struct {
+	Items      interface{} `json:"items"`
+	Limit      int         `json:"limit"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	PrevCursor string      `json:"prevCursor,omitempty"`
+	Total      *int64      `json:"total,omitempty"`
+}
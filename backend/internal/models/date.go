@@ -0,0 +1,82 @@
+// internal/models/date.go
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DateLayout is the ISO 8601 calendar date format used by Date in JSON
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without time of day, written as "2006-01-02" in JSON and
+// stored in a date column
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the given day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// Value implements driver.Valuer
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.Format(DateLayout), nil
+}
+
+// Scan implements sql.Scanner
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = NewDate(v.Date())
+	case string:
+		return d.parse(v)
+	case []byte:
+		return d.parse(string(v))
+	default:
+		return errors.New("tipo inválido para coluna de data")
+	}
+	return nil
+}
+
+// MarshalJSON writes the date as "2006-01-02", or null when it is zero
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Format(DateLayout))
+}
+
+// UnmarshalJSON reads a "2006-01-02" date; null leaves the zero date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.parse(s)
+}
+
+func (d *Date) parse(s string) error {
+	if len(s) > len(DateLayout) {
+		s = s[:len(DateLayout)]
+	}
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return errors.New("data deve estar no formato AAAA-MM-DD")
+	}
+	*d = Date{t}
+	return nil
+}
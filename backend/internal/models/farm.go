@@ -2,55 +2,164 @@
 package models
 
 import (
-	"errors"
+	"encoding/json"
+	"math"
 	"time"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+	"gorm.io/gorm"
 )
 
+// PlantedAreaExceededMessage explains why a year's harvests were rejected
+const PlantedAreaExceededMessage = "a área plantada no período não pode ser maior que a área agrícola da fazenda"
+
+// AreaDiscrepancyTolerance is how far, as a fraction of TotalArea, the area measured from
+// a farm's boundary may be from the declared one before it is flagged
+const AreaDiscrepancyTolerance = 0.05
+
+// Farm is a rural property. Boundary is its optional GeoJSON outline; BoundaryArea and
+// the bounding box of the boundary are measured from it on every write, BoundaryArea
+// in hectares. The centroid locates the farm for the nearby search: it is taken from
+// the boundary when there is one and may be given directly otherwise. Overlaps is only
+// filled in by the writes, with the farms the boundary overlaps.
 type Farm struct {
-	ID              uint      `json:"id" gorm:"primaryKey"`
-	Name            string    `json:"farmName" gorm:"not null"`
-	City            string    `json:"city" gorm:"not null"`
-	State           string    `json:"state" gorm:"not null"`
-	TotalArea       float64   `json:"totalArea" gorm:"not null"`
-	AgricultureArea float64   `json:"arableArea" gorm:"not null"`
-	VegetationArea  float64   `json:"vegetationArea" gorm:"not null"`
-	FarmerID        *uint     `json:"farmer_id"`
-	Harvests        []Harvest `json:"harvests" gorm:"foreignKey:FarmID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	Name            string         `json:"farmName" gorm:"not null"`
+	City            string         `json:"city" gorm:"not null"`
+	State           string         `json:"state" gorm:"not null"`
+	TotalArea       float64        `json:"totalArea" gorm:"not null"`
+	AgricultureArea float64        `json:"arableArea" gorm:"not null"`
+	VegetationArea  float64        `json:"vegetationArea" gorm:"not null"`
+	Boundary        *geo.Geometry  `json:"boundary,omitempty" gorm:"type:jsonb"`
+	BoundaryArea    *float64       `json:"boundaryArea"`
+	BoundaryMinLon  *float64       `json:"-" gorm:"index:idx_farms_boundary_bounds"`
+	BoundaryMinLat  *float64       `json:"-" gorm:"index:idx_farms_boundary_bounds"`
+	BoundaryMaxLon  *float64       `json:"-" gorm:"index:idx_farms_boundary_bounds"`
+	BoundaryMaxLat  *float64       `json:"-" gorm:"index:idx_farms_boundary_bounds"`
+	CentroidLat     *float64       `json:"centroidLat" gorm:"index:idx_farms_centroid"`
+	CentroidLon     *float64       `json:"centroidLon" gorm:"index:idx_farms_centroid"`
+	FarmerID        *uint          `json:"farmer_id"`
+	Harvests        []Harvest      `json:"harvests" gorm:"foreignKey:FarmID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Overlaps        []FarmOverlap  `json:"overlaps,omitempty" gorm:"-"`
+	Version         uint           `json:"version" gorm:"not null;default:1"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 func (f *Farm) Validate() error {
+	var errs validation.Errors
+
 	if f.Name == "" {
-		return errors.New("nome da fazenda é obrigatório")
+		errs.Add("farmName", validation.CodeRequired, "nome da fazenda é obrigatório")
 	}
 
 	if f.City == "" {
-		return errors.New("cidade é obrigatória")
+		errs.Add("city", validation.CodeRequired, "cidade é obrigatória")
 	}
 
 	if f.State == "" {
-		return errors.New("estado é obrigatório")
+		errs.Add("state", validation.CodeRequired, "estado é obrigatório")
 	}
 
 	if f.TotalArea <= 0 {
-		return errors.New("área total deve ser maior que zero")
+		errs.Add("totalArea", validation.CodePositive, "área total deve ser maior que zero")
 	}
 
 	if f.AgricultureArea < 0 {
-		return errors.New("área agrícola não pode ser negativa")
+		errs.Add("arableArea", validation.CodeNonNegative, "área agrícola não pode ser negativa")
 	}
 
 	if f.VegetationArea < 0 {
-		return errors.New("área de vegetação não pode ser negativa")
+		errs.Add("vegetationArea", validation.CodeNonNegative, "área de vegetação não pode ser negativa")
+	}
+
+	if f.Boundary != nil {
+		if err := f.Boundary.Validate(); err != nil {
+			errs.Add("boundary", validation.CodeInvalid, err.Error())
+		}
+	}
+
+	switch {
+	case f.CentroidLat == nil && f.CentroidLon != nil:
+		errs.Add("centroidLat", validation.CodeRequired, "latitude do centroide é obrigatória quando a longitude é informada")
+	case f.CentroidLat != nil && f.CentroidLon == nil:
+		errs.Add("centroidLon", validation.CodeRequired, "longitude do centroide é obrigatória quando a latitude é informada")
+	case f.CentroidLat != nil:
+		if math.Abs(*f.CentroidLat) > 90 {
+			errs.Add("centroidLat", validation.CodeInvalid, "latitude do centroide deve estar entre -90 e 90")
+		}
+		if math.Abs(*f.CentroidLon) > 180 {
+			errs.Add("centroidLon", validation.CodeInvalid, "longitude do centroide deve estar entre -180 e 180")
+		}
 	}
 
 	// Validação da soma das áreas
 	if f.AgricultureArea+f.VegetationArea != f.TotalArea {
-		return errors.New("a soma das áreas agrícola e de vegetação não pode ser maior ou menor que a área total")
+		errs.Add("totalArea", validation.CodeMismatch, "a soma das áreas agrícola e de vegetação não pode ser maior ou menor que a área total")
+	}
+
+	for i := range f.Harvests {
+		errs.Nest(validation.Index("harvests", i), f.Harvests[i].Validate())
 	}
 
-	return nil
+	// The harvests of a planting period must fit in the arable area
+	planted := make(PlantedAreas)
+	for i, harvest := range f.Harvests {
+		if planted.Add(harvest) > f.AgricultureArea {
+			errs.Add(validation.Index("harvests", i)+".plantedArea", validation.CodeExceeded, PlantedAreaExceededMessage)
+		}
+	}
+
+	return errs.Err()
+}
+
+// MeasureBoundary sets BoundaryArea, the bounding box and the centroid from the
+// boundary. Without a boundary the area and the box are cleared and the centroid is
+// left as given.
+func (f *Farm) MeasureBoundary() {
+	if f.Boundary == nil {
+		f.BoundaryArea = nil
+		f.BoundaryMinLon, f.BoundaryMinLat, f.BoundaryMaxLon, f.BoundaryMaxLat = nil, nil, nil, nil
+		return
+	}
+	area := f.Boundary.AreaHectares()
+	f.BoundaryArea = &area
+
+	bounds := f.Boundary.Bounds()
+	f.BoundaryMinLon, f.BoundaryMinLat = &bounds.MinLon, &bounds.MinLat
+	f.BoundaryMaxLon, f.BoundaryMaxLat = &bounds.MaxLon, &bounds.MaxLat
+
+	centroid := f.Boundary.Centroid()
+	lon, lat := centroid[0], centroid[1]
+	f.CentroidLat, f.CentroidLon = &lat, &lon
+}
+
+// AreaDiscrepancy reports whether the area measured from the boundary differs from the
+// declared TotalArea by more than AreaDiscrepancyTolerance
+func (f Farm) AreaDiscrepancy() bool {
+	if f.BoundaryArea == nil || f.TotalArea <= 0 {
+		return false
+	}
+	return math.Abs(*f.BoundaryArea-f.TotalArea) > f.TotalArea*AreaDiscrepancyTolerance
+}
+
+// MarshalJSON adds the area discrepancy flag to the stored fields
+func (f Farm) MarshalJSON() ([]byte, error) {
+	type farm Farm
+	return json.Marshal(struct {
+		farm
+		AreaDiscrepancy bool `json:"areaDiscrepancy"`
+	}{farm(f), f.AreaDiscrepancy()})
+}
+
+// FarmTotals holds the farm count and area sums of the dashboard
+type FarmTotals struct {
+	Count           int
+	TotalArea       float64
+	AgricultureArea float64
+	VegetationArea  float64
 }
 
 // StateCount represents the count of farms by state
@@ -58,3 +167,12 @@ type StateCount struct {
 	State string `json:"state"`
 	Count int    `json:"count"`
 }
+
+// PlantedAreaByPeriod sums the planted area of harvests per planting period
+func PlantedAreaByPeriod(harvests []Harvest) PlantedAreas {
+	planted := make(PlantedAreas)
+	for _, harvest := range harvests {
+		planted.Add(harvest)
+	}
+	return planted
+}
@@ -4,29 +4,71 @@ package models
 import (
 	"errors"
 	"time"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/document"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+	"gorm.io/gorm"
 )
 
+// Farmer owns farms. FederalIdentification is unique among the active farmers only, so
+// the document of a farmer in the trash can be registered again.
 type Farmer struct {
-	ID                    uint      `json:"id" gorm:"primaryKey"`
-	FarmerName            string    `json:"farmerName" gorm:"column:name;not null"`
-	FederalIdentification string    `json:"federalIdentification" gorm:"unique;not null"`
-	Farms                 []Farm    `json:"farms" gorm:"foreignKey:FarmerID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
+	ID                    uint           `json:"id" gorm:"primaryKey"`
+	FarmerName            string         `json:"farmerName" gorm:"column:name;not null"`
+	FederalIdentification string         `json:"federalIdentification" gorm:"not null"`
+	DocumentKind          document.Kind  `json:"documentKind" gorm:"type:varchar(2)"`
+	Farms                 []Farm         `json:"farms" gorm:"foreignKey:FarmerID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Version               uint           `json:"version" gorm:"not null;default:1"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+	DeletedAt             gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 func (f *Farmer) Validate() error {
+	var errs validation.Errors
+
 	if f.FarmerName == "" {
-		return errors.New("nome do fazendeiro é obrigatório")
+		errs.Add("farmerName", validation.CodeRequired, "nome do fazendeiro é obrigatório")
 	}
 
-	if f.FederalIdentification == "" {
-		return errors.New("documento é obrigatório")
+	if err := document.Validate(f.FederalIdentification); err != nil {
+		errs.Add("federalIdentification", documentErrorCode(err), err.Error())
 	}
 
-	if len(f.FederalIdentification) != 11 && len(f.FederalIdentification) != 14 {
-		return errors.New("CPF deve conter 11 dígitos / CNPJ deve conter 14 dígitos")
+	for i := range f.Farms {
+		errs.Nest(validation.Index("farms", i), f.Farms[i].Validate())
+	}
+
+	return errs.Err()
+}
+
+// documentErrorCode maps the document package errors to validation codes
+func documentErrorCode(err error) string {
+	switch {
+	case errors.Is(err, document.ErrEmpty):
+		return validation.CodeRequired
+	case errors.Is(err, document.ErrInvalidCharacters):
+		return "invalid_characters"
+	case errors.Is(err, document.ErrInvalidLength):
+		return "invalid_length"
+	case errors.Is(err, document.ErrRepeatedDigits):
+		return "repeated_digits"
+	case errors.Is(err, document.ErrInvalidCheckDigits):
+		return "invalid_check_digits"
+	default:
+		return validation.CodeInvalid
+	}
+}
+
+// NormalizeDocument stores the federal identification in its canonical digits-only
+// form and records whether it is a CPF (PF) or a CNPJ (PJ)
+func (f *Farmer) NormalizeDocument() error {
+	doc, err := document.Parse(f.FederalIdentification)
+	if err != nil {
+		return err
 	}
 
+	f.FederalIdentification = doc.Number
+	f.DocumentKind = doc.Kind
 	return nil
 }
@@ -0,0 +1,38 @@
+// internal/models/filter.go
+package models
+
+// SortField is one entry of a sort parameter; "-createdAt" sorts by createdAt descending
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// FarmerFilter narrows the farmer list. Empty fields are ignored.
+type FarmerFilter struct {
+	Name     string
+	Document string
+	State    string
+	City     string
+	Culture  string
+	MinArea  *float64
+	MaxArea  *float64
+	Sort     []SortField
+	// CultureID is the catalog culture Culture names, looked up by the services. The
+	// repositories filter on it rather than on Culture, so aliases match too.
+	CultureID *uint
+}
+
+// DashboardFilter narrows every dashboard aggregate to a slice of the farms. Culture,
+// Year and SeasonID select the farms with a matching harvest, or the matching harvests
+// themselves when harvests are aggregated. Empty fields are ignored.
+type DashboardFilter struct {
+	State    string
+	City     string
+	Culture  string
+	Year     *int
+	SeasonID *uint
+	FarmerID *uint
+	// CultureID is the catalog culture Culture names, looked up by the services. The
+	// repositories filter on it rather than on Culture, so aliases match too.
+	CultureID *uint
+}
@@ -2,33 +2,166 @@
 package models
 
 import (
-	"errors"
+	"encoding/json"
+	"math"
 	"time"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+	"gorm.io/gorm"
 )
 
+// Harvest is a crop planted on a farm in a given year, optionally within a Season.
+// PlantedArea is in hectares and Quantity is the amount produced, measured in Unit.
+// Culture is the canonical name of the catalog culture CultureID; on writes either one
+// may be given and the other is filled in from the catalog.
 type Harvest struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Year      int       `json:"year" gorm:"not null"`
-	Culture   string    `json:"culture" gorm:"not null"`
-	FarmID    *uint     `json:"farm_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Year        int            `json:"year" gorm:"not null"`
+	Culture     string         `json:"culture" gorm:"not null"`
+	CultureID   *uint          `json:"culture_id"`
+	PlantedArea float64        `json:"plantedArea" gorm:"not null;default:0"`
+	Quantity    float64        `json:"quantity" gorm:"not null;default:0"`
+	Unit        string         `json:"unit" gorm:"type:varchar(10)"`
+	FarmID      *uint          `json:"farm_id"`
+	SeasonID    *uint          `json:"season_id"`
+	Version     uint           `json:"version" gorm:"not null;default:1"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
-// HarvestCultureCount represents the count of harvests by type
+// Units in which a harvest quantity can be recorded
+const (
+	UnitSacas     = "sacas"
+	UnitToneladas = "toneladas"
+	UnitKg        = "kg"
+)
+
+// HarvestCultureCount counts the harvests of a culture, the farms growing it and the
+// area planted with it. Year is only set when the counts are broken down by year.
 type HarvestCultureCount struct {
-	Culture string `json:"culture"`
-	Count   int    `json:"count"`
+	Culture     string  `json:"culture"`
+	Year        *int    `json:"year,omitempty"`
+	Count       int     `json:"count"`
+	Farms       int     `json:"farms"`
+	PlantedArea float64 `json:"plantedArea"`
+}
+
+// CultureProduction totals the harvests of a culture recorded in the same unit
+type CultureProduction struct {
+	Culture         string   `json:"culture"`
+	Unit            string   `json:"unit"`
+	Harvests        int      `json:"harvests"`
+	PlantedArea     float64  `json:"plantedArea"`
+	Quantity        float64  `json:"quantity"`
+	YieldPerHectare *float64 `json:"yieldPerHectare" gorm:"-"`
 }
 
 func (c *Harvest) Validate() error {
+	var errs validation.Errors
+
 	if c.Year <= 0 {
-		return errors.New("ano da safra é obrigatório")
+		errs.Add("year", validation.CodeRequired, "ano da safra é obrigatório")
+	}
+
+	if c.Culture == "" && c.CultureID == nil {
+		errs.Add("culture", validation.CodeRequired, "tipo de cultivo é obrigatório")
+	}
+
+	if c.PlantedArea < 0 {
+		errs.Add("plantedArea", validation.CodeNonNegative, "área plantada não pode ser negativa")
+	}
+
+	if c.Quantity < 0 {
+		errs.Add("quantity", validation.CodeNonNegative, "quantidade produzida não pode ser negativa")
 	}
 
-	if c.Culture == "" {
-		return errors.New("tipo de cultivo é obrigatório")
+	// an empty unit is filled in from the culture's default unit by UseCulture
+	switch c.Unit {
+	case "", UnitSacas, UnitToneladas, UnitKg:
+	default:
+		errs.Add("unit", validation.CodeInvalid, "unidade deve ser sacas, toneladas ou kg")
 	}
 
-	return nil
+	return errs.Err()
+}
+
+// UseCulture links the harvest to a catalog culture, taking its canonical name and,
+// when the harvest has no unit, its default unit
+func (c *Harvest) UseCulture(culture Culture) {
+	c.CultureID = &culture.ID
+	c.Culture = culture.Name
+	if c.Unit == "" {
+		c.Unit = culture.DefaultUnit
+	}
+}
+
+// MissingUnit reports whether the harvest records a quantity without the unit it is
+// measured in
+func (c Harvest) MissingUnit() bool {
+	return c.Unit == "" && c.Quantity > 0
+}
+
+// PlantingPeriod identifies the harvests that share a farm's arable land: those of the
+// same season or, for harvests without one, of the same year. A harvest without a
+// season may have been planted in any season of its year, so it shares the land of
+// each of them.
+type PlantingPeriod struct {
+	Year     int
+	SeasonID uint
+}
+
+// Period returns the planting period the harvest belongs to
+func (c Harvest) Period() PlantingPeriod {
+	if c.SeasonID != nil {
+		return PlantingPeriod{Year: c.Year, SeasonID: *c.SeasonID}
+	}
+	return PlantingPeriod{Year: c.Year}
+}
+
+// covers reports whether the harvests of p take land in period: p itself and, for a
+// period without a season, every season of the same year
+func (p PlantingPeriod) covers(period PlantingPeriod) bool {
+	return p == period || (p.SeasonID == 0 && p.Year == period.Year)
+}
+
+// PlantedAreas holds the area planted in each planting period of a farm
+type PlantedAreas map[PlantingPeriod]float64
+
+// Add counts the harvest in the periods it takes land in and returns the largest area
+// planted in any of them
+func (a PlantedAreas) Add(harvest Harvest) float64 {
+	period := harvest.Period()
+	if _, ok := a[period]; !ok {
+		// a new season starts with the harvests of its year that have no season
+		a[period] = a[PlantingPeriod{Year: period.Year}]
+	}
+
+	var largest float64
+	for other := range a {
+		if period.covers(other) {
+			a[other] += harvest.PlantedArea
+			largest = math.Max(largest, a[other])
+		}
+	}
+	return largest
+}
+
+// YieldPerHectare returns the quantity produced per planted hectare, or nil when no
+// planted area was recorded
+func (c Harvest) YieldPerHectare() *float64 {
+	if c.PlantedArea <= 0 {
+		return nil
+	}
+	yield := c.Quantity / c.PlantedArea
+	return &yield
+}
+
+// MarshalJSON adds the computed yield to the stored fields
+func (c Harvest) MarshalJSON() ([]byte, error) {
+	type harvest Harvest
+	return json.Marshal(struct {
+		harvest
+		YieldPerHectare *float64 `json:"yieldPerHectare"`
+	}{harvest(c), c.YieldPerHectare()})
 }
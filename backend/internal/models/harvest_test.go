@@ -0,0 +1,40 @@
+// internal/models/harvest_test.go
+package models
+
+import "testing"
+
+func TestHarvest_UseCulture(t *testing.T) {
+	soja := Culture{ID: 1, Name: "Soja", DefaultUnit: UnitSacas}
+	girassol := Culture{ID: 2, Name: "Girassol"}
+
+	// Test cases
+	tests := []struct {
+		name                string
+		harvest             Harvest
+		culture             Culture
+		expectedUnit        string
+		expectedMissingUnit bool
+	}{
+		{"Unit Omitted", Harvest{Culture: "soybean", Quantity: 3000}, soja, UnitSacas, false},
+		{"Unit Given", Harvest{Culture: "Soja", Quantity: 180, Unit: UnitToneladas}, soja, UnitToneladas, false},
+		{"Culture Without Default Unit", Harvest{Culture: "GIRASSOL", Quantity: 50}, girassol, "", true},
+		{"No Quantity", Harvest{Culture: "Girassol"}, girassol, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			harvest := tt.harvest
+			harvest.UseCulture(tt.culture)
+
+			if harvest.CultureID == nil || *harvest.CultureID != tt.culture.ID || harvest.Culture != tt.culture.Name {
+				t.Errorf("Expected culture %d %q, got %v %q", tt.culture.ID, tt.culture.Name, harvest.CultureID, harvest.Culture)
+			}
+			if harvest.Unit != tt.expectedUnit {
+				t.Errorf("Expected unit %q, got %q", tt.expectedUnit, harvest.Unit)
+			}
+			if harvest.MissingUnit() != tt.expectedMissingUnit {
+				t.Errorf("Expected MissingUnit %v, got %v", tt.expectedMissingUnit, harvest.MissingUnit())
+			}
+		})
+	}
+}
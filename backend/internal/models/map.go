@@ -0,0 +1,67 @@
+// internal/models/map.go
+package models
+
+import "github.com/samuel-prates/farm-project/backend/pkg/geo"
+
+// MapFilter narrows the farms drawn on the map. BBox keeps the farms whose boundary
+// reaches into it, or whose centroid lies in it when they have no boundary; Centroids
+// draws each farm as a point; Simplify is the tolerance in meters the boundaries are
+// simplified with, zero to leave them as they are.
+type MapFilter struct {
+	DashboardFilter
+	BBox      *geo.BBox
+	Centroids bool
+	Simplify  float64
+}
+
+// FarmMapEntry is a farm as shown on the map: the properties of its GeoJSON feature
+// plus the boundary and the centroid it is drawn with
+type FarmMapEntry struct {
+	ID              uint          `json:"id"`
+	Name            string        `json:"farmName"`
+	FarmerID        *uint         `json:"farmer_id"`
+	Owner           string        `json:"owner,omitempty"`
+	City            string        `json:"city"`
+	State           string        `json:"state"`
+	TotalArea       float64       `json:"totalArea"`
+	AgricultureArea float64       `json:"arableArea"`
+	VegetationArea  float64       `json:"vegetationArea"`
+	BoundaryArea    *float64      `json:"boundaryArea"`
+	LatestCulture   string        `json:"latestCulture,omitempty"`
+	Boundary        *geo.Geometry `json:"-"`
+	CentroidLat     *float64      `json:"-"`
+	CentroidLon     *float64      `json:"-"`
+}
+
+// Geometry returns what the map draws for the entry: the boundary, simplified with a
+// tolerance in meters when it is not zero, or its centroid as a point when centroids
+// are asked for. Farms with a centroid but no boundary are always drawn as a point,
+// and nil is returned for farms with neither.
+func (e FarmMapEntry) Geometry(centroids bool, tolerance float64) interface{} {
+	switch {
+	case e.Boundary == nil && e.CentroidLat != nil && e.CentroidLon != nil:
+		return geo.Point{*e.CentroidLon, *e.CentroidLat}
+	case e.Boundary == nil:
+		return nil
+	case centroids:
+		return e.Boundary.Centroid()
+	case tolerance > 0:
+		return e.Boundary.Simplify(tolerance)
+	default:
+		return e.Boundary
+	}
+}
+
+// Formats farm boundaries are exported to. Imports detect the format from the file.
+const (
+	BoundaryFormatKML       = "kml"
+	BoundaryFormatKMZ       = "kmz"
+	BoundaryFormatShapefile = "shp"
+)
+
+// BoundaryFile is an exported file of farm boundaries
+type BoundaryFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
@@ -0,0 +1,34 @@
+// internal/models/map_test.go
+package models
+
+import (
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+func TestFarmMapEntry_Geometry(t *testing.T) {
+	lat, lon := -12.55, -55.7
+	boundary := geo.NewPolygon(geo.Ring{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.54}, {-55.7, -12.55}})
+
+	outlined := FarmMapEntry{ID: 1, Boundary: boundary, CentroidLat: &lat, CentroidLon: &lon}
+	if geometry, ok := outlined.Geometry(false, 0).(*geo.Geometry); !ok || geometry != boundary {
+		t.Errorf("Expected the boundary, got %#v", outlined.Geometry(false, 0))
+	}
+	if _, ok := outlined.Geometry(true, 0).(geo.Point); !ok {
+		t.Errorf("Expected the centroid of the boundary, got %#v", outlined.Geometry(true, 0))
+	}
+
+	// A farm with only a centroid is drawn as a point, whatever the options
+	located := FarmMapEntry{ID: 2, CentroidLat: &lat, CentroidLon: &lon}
+	for _, centroids := range []bool{false, true} {
+		point, ok := located.Geometry(centroids, 100).(geo.Point)
+		if !ok || point != (geo.Point{lon, lat}) {
+			t.Errorf("Expected the stored centroid as a point, got %#v", located.Geometry(centroids, 100))
+		}
+	}
+
+	if geometry := (FarmMapEntry{ID: 3}).Geometry(false, 0); geometry != nil {
+		t.Errorf("Expected no geometry, got %#v", geometry)
+	}
+}
@@ -0,0 +1,25 @@
+// internal/models/nearby.go
+package models
+
+// Radius, in kilometers, of the nearby search when none is given and the largest one
+// accepted
+const (
+	DefaultNearbyRadius = 30.0
+	MaxNearbyRadius     = 500.0
+)
+
+// NearbyFilter centers the nearby search on a position. The dashboard filters narrow
+// the farms searched; Culture keeps the ones that harvested it.
+type NearbyFilter struct {
+	DashboardFilter
+	Lat      float64
+	Lon      float64
+	RadiusKm float64
+}
+
+// NearbyFarm is a farm found by the nearby search, with its distance in kilometers from
+// the searched position
+type NearbyFarm struct {
+	Farm     Farm    `json:"farm"`
+	Distance float64 `json:"distanceKm"`
+}
@@ -0,0 +1,35 @@
+// internal/models/overlap.go
+package models
+
+import "github.com/samuel-prates/farm-project/backend/pkg/geo"
+
+// OverlapMinimumArea is the smallest area, in hectares, two farms must share to be
+// reported as overlapping. Neighbours traced from different sources often cross each
+// other's border by thin slivers smaller than that.
+const OverlapMinimumArea = 0.1
+
+// FarmBoundary is the outline of a farm as compared by the overlap checks
+type FarmBoundary struct {
+	ID           uint
+	Name         string
+	Boundary     *geo.Geometry
+	BoundaryArea *float64
+}
+
+// FarmOverlap is another farm whose boundary overlaps the one of a farm. Area is the
+// overlap in hectares and Percentage the share of the farm's boundary area it covers.
+type FarmOverlap struct {
+	FarmID     uint    `json:"farmId"`
+	FarmName   string  `json:"farmName"`
+	Area       float64 `json:"overlapArea"`
+	Percentage float64 `json:"percentage"`
+}
+
+// FarmOverlapPair is a pair of farms whose boundaries overlap, the lower ID first
+type FarmOverlapPair struct {
+	FarmID        uint    `json:"farmId"`
+	FarmName      string  `json:"farmName"`
+	OtherFarmID   uint    `json:"otherFarmId"`
+	OtherFarmName string  `json:"otherFarmName"`
+	Area          float64 `json:"overlapArea"`
+}
@@ -0,0 +1,28 @@
+// internal/models/search.go
+package models
+
+// Kinds of record returned by the search endpoint
+const (
+	SearchTypeFarmer = "farmer"
+	SearchTypeFarm   = "farm"
+)
+
+// SearchHit is a farmer or farm matching a search, with the fields that matched
+// highlighted in <mark> tags
+type SearchHit struct {
+	Type       string            `json:"type"`
+	ID         uint              `json:"id"`
+	Name       string            `json:"name"`
+	Document   string            `json:"document,omitempty"`
+	City       string            `json:"city,omitempty"`
+	State      string            `json:"state,omitempty"`
+	ParentID   *uint             `json:"parent_id"`
+	Rank       float64           `json:"rank"`
+	Highlights map[string]string `json:"highlights,omitempty" gorm:"-"`
+}
+
+// SearchResult holds the hits of a search, best ranked first
+type SearchResult struct {
+	Query string      `json:"query"`
+	Items []SearchHit `json:"items"`
+}
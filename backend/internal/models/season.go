@@ -0,0 +1,64 @@
+// internal/models/season.go
+package models
+
+import (
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// Crop cycles of a season. Seasons of different cycles may overlap, as the safrinha
+// is planted on the same land right after the main crop.
+const (
+	CycleSafra    = "safra"
+	CycleSafrinha = "safrinha"
+	CycleInverno  = "inverno"
+)
+
+// Season is a crop season such as "Safra 2023/2024" that harvests can be assigned to
+type Season struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex"`
+	Cycle     string    `json:"cycle" gorm:"type:varchar(10);not null"`
+	StartDate Date      `json:"startDate" gorm:"type:date;not null"`
+	EndDate   Date      `json:"endDate" gorm:"type:date;not null"`
+	Harvests  []Harvest `json:"-" gorm:"foreignKey:SeasonID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SeasonProduction totals the harvests of a culture in a season recorded in the same unit
+type SeasonProduction struct {
+	SeasonID uint   `json:"season_id"`
+	Season   string `json:"season"`
+	Cycle    string `json:"cycle"`
+	CultureProduction
+}
+
+func (s *Season) Validate() error {
+	var errs validation.Errors
+
+	if s.Name == "" {
+		errs.Add("name", validation.CodeRequired, "nome da temporada é obrigatório")
+	}
+
+	switch s.Cycle {
+	case CycleSafra, CycleSafrinha, CycleInverno:
+	case "":
+		errs.Add("cycle", validation.CodeRequired, "ciclo da temporada é obrigatório")
+	default:
+		errs.Add("cycle", validation.CodeInvalid, "ciclo deve ser safra, safrinha ou inverno")
+	}
+
+	if s.StartDate.IsZero() {
+		errs.Add("startDate", validation.CodeRequired, "data de início é obrigatória")
+	}
+
+	if s.EndDate.IsZero() {
+		errs.Add("endDate", validation.CodeRequired, "data de término é obrigatória")
+	} else if !s.StartDate.IsZero() && !s.EndDate.After(s.StartDate.Time) {
+		errs.Add("endDate", validation.CodeInvalid, "data de término deve ser posterior à data de início")
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,20 @@
+// internal/models/trash.go
+package models
+
+import "time"
+
+// Trash item types
+const (
+	TrashTypeFarmer  = "farmer"
+	TrashTypeFarm    = "farm"
+	TrashTypeHarvest = "harvest"
+)
+
+// TrashItem is a soft-deleted farmer, farm or harvest waiting to be restored or purged
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  *uint     `json:"parent_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
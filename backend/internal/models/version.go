@@ -0,0 +1,68 @@
+// internal/models/version.go
+package models
+
+import (
+	"fmt"
+	"hash"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+// Every row starts at version 1 and the repositories bump the version on each update,
+// so a version seen by a client identifies the state it was read in.
+
+func (f *Farmer) BeforeCreate(tx *gorm.DB) error {
+	f.Version = 1
+	return nil
+}
+
+func (f *Farm) BeforeCreate(tx *gorm.DB) error {
+	f.Version = 1
+	return nil
+}
+
+func (c *Harvest) BeforeCreate(tx *gorm.DB) error {
+	c.Version = 1
+	return nil
+}
+
+// ETag returns the entity tag of the farmer representation. It covers the farms and
+// harvests embedded in it, so a change to any of them produces a new tag.
+func (f *Farmer) ETag() string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "farmer:%d:%d;", f.ID, f.Version)
+	for i := range f.Farms {
+		f.Farms[i].writeVersion(h)
+	}
+	return formatETag(h)
+}
+
+// ETag returns the entity tag of the farm representation, including its harvests
+func (f *Farm) ETag() string {
+	h := fnv.New64a()
+	f.writeVersion(h)
+	return formatETag(h)
+}
+
+// ETag returns the entity tag of the harvest representation
+func (c *Harvest) ETag() string {
+	h := fnv.New64a()
+	c.writeVersion(h)
+	return formatETag(h)
+}
+
+func (f *Farm) writeVersion(h hash.Hash64) {
+	fmt.Fprintf(h, "farm:%d:%d;", f.ID, f.Version)
+	for i := range f.Harvests {
+		f.Harvests[i].writeVersion(h)
+	}
+}
+
+func (c *Harvest) writeVersion(h hash.Hash64) {
+	fmt.Fprintf(h, "harvest:%d:%d;", c.ID, c.Version)
+}
+
+func formatETag(h hash.Hash64) string {
+	return fmt.Sprintf(`"%016x"`, h.Sum64())
+}
@@ -0,0 +1,46 @@
+// internal/repository/audit_repository.go
+package repository
+
+import (
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var auditMessages = entityMessages{
+	notFound: "evento de auditoria não encontrado",
+	conflict: "evento de auditoria conflita com um registro existente",
+}
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(event *models.AuditEvent) error {
+	return translateError(r.db.Create(event).Error, auditMessages)
+}
+
+// GetByEntity lists the events of an entity and of its children, newest first
+func (r *AuditRepository) GetByEntity(entityType string, entityID uint, params models.PaginationParams) ([]models.AuditEvent, int64, error) {
+	var events []models.AuditEvent
+	var total int64
+
+	query := r.db.Model(&models.AuditEvent{}).
+		Where("(entity_type = ? AND entity_id = ?) OR (parent_type = ? AND parent_id = ?)", entityType, entityID, entityType, entityID)
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, auditMessages)
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(params.Limit).Find(&events).Error; err != nil {
+		return nil, 0, translateError(err, auditMessages)
+	}
+
+	return events, total, nil
+}
@@ -0,0 +1,128 @@
+// internal/repository/culture_repository.go
+package repository
+
+import (
+	"context"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CultureRepository struct {
+	db *gorm.DB
+}
+
+func NewCultureRepository(db *gorm.DB) *CultureRepository {
+	return &CultureRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries are bound to ctx
+func (r *CultureRepository) WithContext(ctx context.Context) *CultureRepository {
+	return &CultureRepository{db: r.db.WithContext(ctx)}
+}
+
+func (r *CultureRepository) Create(culture *models.Culture) (*models.Culture, error) {
+	if err := r.db.Create(culture).Error; err != nil {
+		return nil, translateError(err, cultureMessages)
+	}
+	return culture, nil
+}
+
+// Update saves the culture, replaces its aliases and renames the harvests that
+// reference it, including the ones in the trash. It should run inside a unit of work.
+func (r *CultureRepository) Update(culture *models.Culture) (*models.Culture, error) {
+	result := r.db.Model(culture).
+		Select("*").
+		Omit("ID", "CreatedAt", clause.Associations).
+		Updates(culture)
+	if err := notFoundIfNoRows(result, cultureMessages); err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("culture_id = ?", culture.ID).Delete(&models.CultureAlias{}).Error; err != nil {
+		return nil, translateError(err, cultureMessages)
+	}
+	for i := range culture.Aliases {
+		culture.Aliases[i].ID = 0
+		culture.Aliases[i].CultureID = culture.ID
+	}
+	if len(culture.Aliases) > 0 {
+		if err := r.db.Create(&culture.Aliases).Error; err != nil {
+			return nil, translateError(err, cultureMessages)
+		}
+	}
+
+	if err := r.db.Unscoped().
+		Model(&models.Harvest{}).
+		Where("culture_id = ? AND culture <> ?", culture.ID, culture.Name).
+		Updates(map[string]interface{}{
+			"culture": culture.Name,
+			"version": gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return nil, translateError(err, cultureMessages)
+	}
+
+	return r.GetByID(culture.ID)
+}
+
+// Delete removes the culture and its aliases. It fails with a conflict while harvests,
+// including the ones in the trash, still reference it.
+func (r *CultureRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Culture{}, id)
+	return notFoundIfNoRows(result, cultureMessages)
+}
+
+func (r *CultureRepository) GetByID(id uint) (*models.Culture, error) {
+	var culture models.Culture
+	if err := r.withAliases().First(&culture, id).Error; err != nil {
+		return nil, translateError(err, cultureMessages)
+	}
+	return &culture, nil
+}
+
+// GetAll lists the catalog in name order
+func (r *CultureRepository) GetAll(params models.PaginationParams) ([]models.Culture, int64, error) {
+	var cultures []models.Culture
+	var total int64
+
+	// Count total records
+	if err := r.db.Model(&models.Culture{}).Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, cultureMessages)
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := r.withAliases().Order("name, id").Offset(offset).Limit(params.Limit).Find(&cultures).Error; err != nil {
+		return nil, 0, translateError(err, cultureMessages)
+	}
+
+	return cultures, total, nil
+}
+
+// FindByNormalizedName returns the culture whose name or one of whose aliases has the
+// given normalized form, or nil when there is none
+func (r *CultureRepository) FindByNormalizedName(normalized string) (*models.Culture, error) {
+	aliases := r.db.Session(&gorm.Session{NewDB: true}).
+		Model(&models.CultureAlias{}).
+		Select("culture_id").
+		Where("normalized_name = ?", normalized)
+
+	var cultures []models.Culture
+	if err := r.withAliases().
+		Where("normalized_name = ? OR id IN (?)", normalized, aliases).
+		Limit(1).
+		Find(&cultures).Error; err != nil {
+		return nil, translateError(err, cultureMessages)
+	}
+	if len(cultures) == 0 {
+		return nil, nil
+	}
+	return &cultures[0], nil
+}
+
+func (r *CultureRepository) withAliases() *gorm.DB {
+	return r.db.Preload("Aliases", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	})
+}
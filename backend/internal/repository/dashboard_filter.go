@@ -0,0 +1,58 @@
+// internal/repository/dashboard_filter.go
+package repository
+
+import (
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// applyFarmDashboardFilter restricts a query over farms to the dashboard slice
+func applyFarmDashboardFilter(query *gorm.DB, filter models.DashboardFilter) *gorm.DB {
+	query = applyFarmColumnsFilter(query, filter)
+
+	if filter.CultureID != nil || filter.Year != nil || filter.SeasonID != nil {
+		harvests := query.Session(&gorm.Session{NewDB: true}).
+			Table("harvests").
+			Select("1").
+			Where("harvests.farm_id = farms.id AND harvests.deleted_at IS NULL")
+		harvests = applyHarvestColumnsFilter(harvests, filter)
+		query = query.Where("EXISTS (?)", harvests)
+	}
+	return query
+}
+
+// applyHarvestDashboardFilter restricts a query over harvests to the dashboard slice,
+// joining the active farm each harvest belongs to
+func applyHarvestDashboardFilter(query *gorm.DB, filter models.DashboardFilter) *gorm.DB {
+	query = query.Joins("JOIN farms ON farms.id = harvests.farm_id AND farms.deleted_at IS NULL")
+	query = applyFarmColumnsFilter(query, filter)
+	return applyHarvestColumnsFilter(query, filter)
+}
+
+func applyFarmColumnsFilter(query *gorm.DB, filter models.DashboardFilter) *gorm.DB {
+	if filter.State != "" {
+		query = query.Where("UPPER(farms.state) = ?", strings.ToUpper(filter.State))
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(farms.city) = ?", strings.ToLower(filter.City))
+	}
+	if filter.FarmerID != nil {
+		query = query.Where("farms.farmer_id = ?", *filter.FarmerID)
+	}
+	return query
+}
+
+func applyHarvestColumnsFilter(query *gorm.DB, filter models.DashboardFilter) *gorm.DB {
+	if filter.CultureID != nil {
+		query = query.Where("harvests.culture_id = ?", *filter.CultureID)
+	}
+	if filter.Year != nil {
+		query = query.Where("harvests.year = ?", *filter.Year)
+	}
+	if filter.SeasonID != nil {
+		query = query.Where("harvests.season_id = ?", *filter.SeasonID)
+	}
+	return query
+}
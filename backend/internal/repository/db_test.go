@@ -0,0 +1,52 @@
+// internal/repository/db_test.go
+package repository_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/database"
+	"gorm.io/gorm"
+)
+
+// testDatabaseURLEnv names the Postgres database the repository tests run against.
+// The tests are skipped when it is not set.
+const testDatabaseURLEnv = "TEST_DATABASE_URL"
+
+// testDB migrates the test database and returns a transaction that is rolled back when
+// the test ends, so tests neither see nor leave each other's rows
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := testConn(t)
+	tx := db.Begin()
+	if tx.Error != nil {
+		t.Fatalf("Failed to begin transaction: %v", tx.Error)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+
+	return tx
+}
+
+// testConn migrates the test database and returns a connection closed when the test
+// ends. Unlike testDB it does not isolate the test, which must remove what it writes.
+func testConn(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testDatabaseURLEnv)
+	if dsn == "" {
+		t.Skipf("%s not set, skipping repository test", testDatabaseURLEnv)
+	}
+
+	db, err := database.Connect(dsn)
+	if err != nil {
+		t.Fatalf("Failed to connect to test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
@@ -0,0 +1,129 @@
+// internal/repository/errors.go
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// entityMessages holds the user-facing messages for the errors of one entity
+type entityMessages struct {
+	notFound string
+	conflict string
+	stale    string
+}
+
+var (
+	farmerMessages = entityMessages{
+		notFound: "fazendeiro não encontrado",
+		conflict: "já existe um fazendeiro com este documento",
+		stale:    "o fazendeiro foi alterado por outra requisição",
+	}
+	farmMessages = entityMessages{
+		notFound: "fazenda não encontrada",
+		conflict: "fazenda conflita com um registro existente",
+		stale:    "a fazenda foi alterada por outra requisição",
+	}
+	harvestMessages = entityMessages{
+		notFound: "safra não encontrada",
+		conflict: "safra conflita com um registro existente",
+		stale:    "a safra foi alterada por outra requisição",
+	}
+	cultureMessages = entityMessages{
+		notFound: "cultura não encontrada",
+		conflict: "já existe uma cultura com este nome ou apelido",
+	}
+	seasonMessages = entityMessages{
+		notFound: "temporada não encontrada",
+		conflict: "já existe uma temporada com este nome",
+	}
+)
+
+// translateError converts gorm and Postgres errors into apperrors kinds
+func translateError(err error, messages entityMessages) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.Wrap(apperrors.ErrNotFound, messages.notFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			return apperrors.Wrap(apperrors.ErrConflict, messages.conflict, err)
+		case pgErr.Code == pgForeignKeyViolation:
+			return apperrors.Wrap(apperrors.ErrConflict, "registro referenciado não existe ou ainda está em uso", err)
+		case isUnavailableCode(pgErr.Code):
+			return apperrors.Wrap(apperrors.ErrUnavailable, "banco de dados indisponível", err)
+		}
+		return err
+	}
+
+	if isConnectionError(err) {
+		return apperrors.Wrap(apperrors.ErrUnavailable, "banco de dados indisponível", err)
+	}
+
+	return err
+}
+
+// isUnavailableCode matches connection exceptions (08), insufficient resources (53)
+// and operator intervention such as shutdowns (57P)
+func isUnavailableCode(code string) bool {
+	return strings.HasPrefix(code, "08") || strings.HasPrefix(code, "53") || strings.HasPrefix(code, "57P")
+}
+
+func isConnectionError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// notFoundIfNoRows reports a missing record when a write affected nothing
+func notFoundIfNoRows(result *gorm.DB, messages entityMessages) error {
+	if result.Error != nil {
+		return translateError(result.Error, messages)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound(messages.notFound)
+	}
+	return nil
+}
+
+// staleIfNoRows tells apart the two reasons a versioned update can affect nothing: the
+// record is gone, or it moved past the expected version
+func staleIfNoRows(tx *gorm.DB, result *gorm.DB, model interface{}, id uint, messages entityMessages) error {
+	if result.Error != nil {
+		return translateError(result.Error, messages)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return translateError(err, messages)
+	}
+	if count == 0 {
+		return apperrors.NotFound(messages.notFound)
+	}
+	return apperrors.PreconditionFailed(messages.stale)
+}
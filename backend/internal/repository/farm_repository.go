@@ -2,8 +2,14 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FarmRepository struct {
@@ -14,90 +20,310 @@ func NewFarmRepository(db *gorm.DB) *FarmRepository {
 	return &FarmRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries are bound to ctx
+func (r *FarmRepository) WithContext(ctx context.Context) *FarmRepository {
+	return &FarmRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *FarmRepository) Create(farm *models.Farm) (*models.Farm, error) {
 	if err := r.db.Create(farm).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, farmMessages)
 	}
 	return farm, nil
 }
 
+// Update writes the farm columns and, when Harvests is not nil, reconciles its harvests
+// by ID inside a single transaction. farm.Version must hold the version the caller read.
 func (r *FarmRepository) Update(farm *models.Farm) (*models.Farm, error) {
-	if err := r.db.Save(farm).Error; err != nil {
-		return nil, err
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expected := farm.Version
+		farm.Version = expected + 1
+
+		result := tx.Model(farm).
+			Where("version = ?", expected).
+			Select("*").
+			Omit("ID", "CreatedAt", "DeletedAt", clause.Associations).
+			Updates(farm)
+		if err := staleIfNoRows(tx, result, &models.Farm{}, farm.ID, farmMessages); err != nil {
+			return err
+		}
+
+		if farm.Harvests == nil {
+			return nil
+		}
+		return reconcileHarvests(tx, farm.ID, farm.Harvests)
+	})
+	if err != nil {
+		return nil, translateError(err, farmMessages)
 	}
-	return farm, nil
+
+	return r.GetByID(farm.ID)
 }
 
+// Delete soft-deletes the farm and its harvests
 func (r *FarmRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Farm{}, id).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		deletedAt := time.Now()
+
+		result := tx.Model(&models.Farm{}).Where("id = ?", id).UpdateColumn("deleted_at", deletedAt)
+		if err := notFoundIfNoRows(result, farmMessages); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Harvest{}).Where("farm_id = ?", id).UpdateColumn("deleted_at", deletedAt).Error
+	})
+	return translateError(err, farmMessages)
 }
 
 func (r *FarmRepository) GetByID(id uint) (*models.Farm, error) {
 	var farm models.Farm
 	if err := r.db.Preload("Harvests").First(&farm, id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, farmMessages)
 	}
 	return &farm, nil
 }
 
+func (r *FarmRepository) Exists(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Farm{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, translateError(err, farmMessages)
+	}
+	return count > 0, nil
+}
+
+// GetAll lists the farms without their boundaries, which only GetByID loads
 func (r *FarmRepository) GetAll(params models.PaginationParams) ([]models.Farm, int64, error) {
 	var farms []models.Farm
 	var total int64
 
 	// Count total records
 	if err := r.db.Model(&models.Farm{}).Count(&total).Error; err != nil {
-		return nil, 0, err
+		return nil, 0, translateError(err, farmMessages)
 	}
 
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := r.db.Offset(offset).Limit(params.Limit).Find(&farms).Error; err != nil {
-		return nil, 0, err
+	if err := r.db.Omit("Boundary").Offset(offset).Limit(params.Limit).Find(&farms).Error; err != nil {
+		return nil, 0, translateError(err, farmMessages)
+	}
+
+	return farms, total, nil
+}
+
+// GetPage returns the farms on the cursor's page in ID order, with the cursors of the
+// neighbouring pages
+func (r *FarmRepository) GetPage(c cursor.Cursor, limit int) ([]models.Farm, *cursor.Cursor, *cursor.Cursor, error) {
+	var farms []models.Farm
+	if err := applyKeyset(r.db.Model(&models.Farm{}).Omit("Boundary"), "farms.id", c, limit).Find(&farms).Error; err != nil {
+		return nil, nil, nil, translateError(err, farmMessages)
+	}
+
+	farms, next, prev := keysetPage(farms, c, limit, func(f models.Farm) uint { return f.ID })
+	return farms, next, prev, nil
+}
+
+func (r *FarmRepository) GetAllByFarmer(farmerID uint, params models.PaginationParams) ([]models.Farm, int64, error) {
+	var farms []models.Farm
+	var total int64
+
+	query := r.db.Model(&models.Farm{}).Where("farmer_id = ?", farmerID)
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, farmMessages)
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Omit("Boundary").Offset(offset).Limit(params.Limit).Preload("Harvests").Find(&farms).Error; err != nil {
+		return nil, 0, translateError(err, farmMessages)
 	}
 
 	return farms, total, nil
 }
 
 // Methods for dashboard
-func (r *FarmRepository) Count() (int, error) {
+func (r *FarmRepository) Count(filter models.DashboardFilter) (int, error) {
 	var count int64
-	if err := r.db.Model(&models.Farm{}).Count(&count).Error; err != nil {
-		return 0, err
+	if err := applyFarmDashboardFilter(r.db.Model(&models.Farm{}), filter).Count(&count).Error; err != nil {
+		return 0, translateError(err, farmMessages)
 	}
 	return int(count), nil
 }
 
-func (r *FarmRepository) SumTotalArea() (float64, error) {
-	var sum float64
-	if err := r.db.Model(&models.Farm{}).Select("SUM(total_area)").Scan(&sum).Error; err != nil {
-		return 0, err
+// Totals counts the farms and sums their areas in a single statement
+func (r *FarmRepository) Totals(filter models.DashboardFilter) (models.FarmTotals, error) {
+	var totals models.FarmTotals
+	if err := applyFarmDashboardFilter(r.db.Model(&models.Farm{}), filter).
+		Select("COUNT(*) AS count, " +
+			"COALESCE(SUM(total_area), 0) AS total_area, " +
+			"COALESCE(SUM(agriculture_area), 0) AS agriculture_area, " +
+			"COALESCE(SUM(vegetation_area), 0) AS vegetation_area").
+		Scan(&totals).Error; err != nil {
+		return models.FarmTotals{}, translateError(err, farmMessages)
 	}
-	return sum, nil
+	return totals, nil
 }
 
-func (r *FarmRepository) CountByState() ([]models.StateCount, error) {
+func (r *FarmRepository) CountByState(filter models.DashboardFilter) ([]models.StateCount, error) {
 	var results []models.StateCount
-	if err := r.db.Model(&models.Farm{}).
+	if err := applyFarmDashboardFilter(r.db.Model(&models.Farm{}), filter).
 		Select("state, COUNT(*) as count").
 		Group("state").
 		Scan(&results).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, farmMessages)
 	}
 	return results, nil
 }
 
-func (r *FarmRepository) SumAgricultureArea() (float64, error) {
-	var sum float64
-	if err := r.db.Model(&models.Farm{}).Select("SUM(agriculture_area)").Scan(&sum).Error; err != nil {
-		return 0, err
+// GetMapEntries returns the farms with a boundary or a centroid in the dashboard slice,
+// with the owner's name and the culture of the latest harvest. With a box, only the
+// farms whose bounding box meets it, or whose centroid lies in it when they have no
+// boundary, are returned.
+func (r *FarmRepository) GetMapEntries(filter models.DashboardFilter, box *geo.BBox) ([]models.FarmMapEntry, error) {
+	var entries []models.FarmMapEntry
+	if err := applyMapBoundsFilter(applyFarmDashboardFilter(r.mapEntries(), filter), box).
+		Where("(farms.boundary IS NOT NULL OR farms.centroid_lat IS NOT NULL)").
+		Order("farms.id").
+		Scan(&entries).Error; err != nil {
+		return nil, translateError(err, farmMessages)
 	}
-	return sum, nil
+	return entries, nil
 }
 
-func (r *FarmRepository) SumVegetationArea() (float64, error) {
-	var sum float64
-	if err := r.db.Model(&models.Farm{}).Select("SUM(vegetation_area)").Scan(&sum).Error; err != nil {
-		return 0, err
+// GetMapEntry returns the farm as shown on the map, with or without a boundary
+func (r *FarmRepository) GetMapEntry(id uint) (*models.FarmMapEntry, error) {
+	var entry models.FarmMapEntry
+	result := r.mapEntries().Where("farms.id = ?", id).Limit(1).Scan(&entry)
+	if err := notFoundIfNoRows(result, farmMessages); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetBoundaries returns the outline of the farms with a boundary. With a box, only the
+// farms whose bounding box meets it are returned. excludeID, when not zero, leaves
+// that farm out.
+func (r *FarmRepository) GetBoundaries(box *geo.BBox, excludeID uint) ([]models.FarmBoundary, error) {
+	query := applyBoundsFilter(r.db.Model(&models.Farm{}), box).
+		Select("id, name, boundary, boundary_area").
+		Where("boundary IS NOT NULL")
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+
+	var boundaries []models.FarmBoundary
+	if err := query.Order("id").Scan(&boundaries).Error; err != nil {
+		return nil, translateError(err, farmMessages)
+	}
+	return boundaries, nil
+}
+
+// centroidDistance is the haversine distance in meters from the farm centroid to a
+// position, the same geo.Distance measures. It takes the earth radius and the
+// position's latitude, latitude again and longitude as arguments.
+const centroidDistance = "2 * ? * ASIN(LEAST(1, SQRT(" +
+	"POWER(SIN(RADIANS(farms.centroid_lat - ?) / 2), 2) + " +
+	"COS(RADIANS(?)) * COS(RADIANS(farms.centroid_lat)) * POWER(SIN(RADIANS(farms.centroid_lon - ?) / 2), 2))))"
+
+// farmDistance is a farm found by GetNearby, before the farm itself is loaded
+type farmDistance struct {
+	ID       uint
+	Distance float64
+}
+
+// GetNearby returns a page of the farms in the dashboard slice whose centroid lies
+// within radius meters of center, nearest first, and how many there are in all
+func (r *FarmRepository) GetNearby(center geo.Position, radius float64, filter models.DashboardFilter, params models.PaginationParams) ([]models.NearbyFarm, int64, error) {
+	// The box only narrows the farms down, on the centroid index; the distance decides
+	box := geo.Around(center, radius)
+	located := applyFarmDashboardFilter(r.db.Model(&models.Farm{}), filter).
+		Select("farms.id, "+centroidDistance+" AS distance", geo.MeanEarthRadius, center.Lat(), center.Lat(), center.Lon()).
+		Where("farms.centroid_lat BETWEEN ? AND ? AND farms.centroid_lon BETWEEN ? AND ?",
+			box.MinLat, box.MaxLat, box.MinLon, box.MaxLon)
+	query := r.db.Table("(?) AS located", located).Where("located.distance <= ?", radius)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, farmMessages)
+	}
+
+	var page []farmDistance
+	offset := (params.Page - 1) * params.Limit
+	if err := query.
+		Select("located.id, located.distance").
+		Order("located.distance, located.id").
+		Offset(offset).
+		Limit(params.Limit).
+		Scan(&page).Error; err != nil {
+		return nil, 0, translateError(err, farmMessages)
+	}
+
+	ids := make([]uint, len(page))
+	for i, found := range page {
+		ids[i] = found.ID
 	}
-	return sum, nil
+	farms, err := r.GetByIDs(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[uint]models.Farm, len(farms))
+	for _, farm := range farms {
+		byID[farm.ID] = farm
+	}
+
+	nearby := make([]models.NearbyFarm, 0, len(page))
+	for _, found := range page {
+		if farm, ok := byID[found.ID]; ok {
+			nearby = append(nearby, models.NearbyFarm{Farm: farm, Distance: found.Distance / 1000})
+		}
+	}
+	return nearby, total, nil
+}
+
+// GetByIDs returns the farms with the given IDs, with their harvests but without their
+// boundaries, in ID order. IDs of farms that do not exist are skipped.
+func (r *FarmRepository) GetByIDs(ids []uint) ([]models.Farm, error) {
+	var farms []models.Farm
+	if len(ids) == 0 {
+		return farms, nil
+	}
+
+	if err := r.db.Omit("Boundary").Preload("Harvests").Where("id IN ?", ids).Order("id").Find(&farms).Error; err != nil {
+		return nil, translateError(err, farmMessages)
+	}
+	return farms, nil
+}
+
+// applyBoundsFilter keeps the farms whose bounding box meets box, using the indexed
+// bounds stored with the boundary. A nil box keeps every farm.
+func applyBoundsFilter(query *gorm.DB, box *geo.BBox) *gorm.DB {
+	if box == nil {
+		return query
+	}
+	return query.Where("farms.boundary_min_lon <= ? AND farms.boundary_max_lon >= ? AND farms.boundary_min_lat <= ? AND farms.boundary_max_lat >= ?",
+		box.MaxLon, box.MinLon, box.MaxLat, box.MinLat)
+}
+
+// applyMapBoundsFilter is applyBoundsFilter for the map, which also draws the farms
+// that have a centroid but no boundary
+func applyMapBoundsFilter(query *gorm.DB, box *geo.BBox) *gorm.DB {
+	if box == nil {
+		return query
+	}
+	return query.Where("(farms.boundary_min_lon <= ? AND farms.boundary_max_lon >= ? AND farms.boundary_min_lat <= ? AND farms.boundary_max_lat >= ?) OR "+
+		"(farms.boundary IS NULL AND farms.centroid_lat BETWEEN ? AND ? AND farms.centroid_lon BETWEEN ? AND ?)",
+		box.MaxLon, box.MinLon, box.MaxLat, box.MinLat,
+		box.MinLat, box.MaxLat, box.MinLon, box.MaxLon)
+}
+
+func (r *FarmRepository) mapEntries() *gorm.DB {
+	return r.db.Model(&models.Farm{}).
+		Select("farms.id, farms.name, farms.farmer_id, COALESCE(farmers.name, '') AS owner, " +
+			"farms.city, farms.state, farms.total_area, farms.agriculture_area, farms.vegetation_area, " +
+			"farms.boundary, farms.boundary_area, farms.centroid_lat, farms.centroid_lon, " +
+			"COALESCE((SELECT harvests.culture FROM harvests " +
+			"WHERE harvests.farm_id = farms.id AND harvests.deleted_at IS NULL " +
+			"ORDER BY harvests.year DESC, harvests.id DESC LIMIT 1), '') AS latest_culture").
+		Joins("LEFT JOIN farmers ON farmers.id = farms.farmer_id AND farmers.deleted_at IS NULL")
 }
@@ -0,0 +1,260 @@
+// internal/repository/farm_repository_test.go
+package repository_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+func TestFarmRepository_GetMapEntries(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmRepository(db)
+
+	farmer, err := repository.NewFarmerRepository(db).Create(&models.Farmer{
+		FarmerName: "João da Silva", FederalIdentification: "52998224725",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create farmer: %v", err)
+	}
+
+	mapped := createFarm(t, db, "Fazenda Boa Vista", "MT")
+	mapped.FarmerID = &farmer.ID
+	mapped.Boundary = geo.NewPolygon(geo.Ring{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.54}, {-55.7, -12.55}})
+	mapped.MeasureBoundary()
+	if _, err := repo.Update(mapped); err != nil {
+		t.Fatalf("Failed to set boundary: %v", err)
+	}
+	createHarvest(t, db, mapped, "Milho", 2023, 100)
+	createHarvest(t, db, mapped, "Soja", 2024, 100)
+
+	// Farms with only a centroid are drawn as a point; farms with neither have no place
+	// on the map
+	located := createFarm(t, db, "Fazenda Só Centroide", "MT")
+	lat, lon := -11.86, -55.5
+	located.CentroidLat, located.CentroidLon = &lat, &lon
+	if _, err := repo.Update(located); err != nil {
+		t.Fatalf("Failed to set centroid: %v", err)
+	}
+	unmapped := createFarm(t, db, "Fazenda Sem Mapa", "MT")
+	createHarvest(t, db, unmapped, "Soja", 2024, 100)
+
+	entries, err := repo.GetMapEntries(models.DashboardFilter{State: "mt"}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %+v", len(entries), entries)
+	}
+	if point := entries[1]; point.ID != located.ID || point.Boundary != nil || point.CentroidLat == nil || *point.CentroidLat != lat || *point.CentroidLon != lon {
+		t.Errorf("Expected the farm located by its centroid, got %+v", point)
+	}
+
+	entry := entries[0]
+	if entry.ID != mapped.ID || entry.Name != "Fazenda Boa Vista" || entry.Owner != "João da Silva" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if entry.LatestCulture != "Soja" {
+		t.Errorf("Expected the culture of the latest harvest, got %q", entry.LatestCulture)
+	}
+	if entry.Boundary == nil || entry.Boundary.Type != geo.TypePolygon || entry.BoundaryArea == nil {
+		t.Errorf("Expected the boundary and its area, got %+v and %v", entry.Boundary, entry.BoundaryArea)
+	}
+
+	entries, err = repo.GetMapEntries(models.DashboardFilter{Culture: "Trigo", CultureID: cultureID(t, db, "Trigo")}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries for a culture never harvested, got %+v", entries)
+	}
+
+	// The box meets the corner of the boundary
+	entries, err = repo.GetMapEntries(models.DashboardFilter{}, &geo.BBox{MinLon: -55.695, MinLat: -12.6, MaxLon: -55.6, MaxLat: -12.545})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != mapped.ID {
+		t.Errorf("Expected the farm whose boundary meets the box, got %+v", entries)
+	}
+
+	entries, err = repo.GetMapEntries(models.DashboardFilter{}, &geo.BBox{MinLon: -56, MinLat: -13, MaxLon: -55.8, MaxLat: -12.8})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no entries outside the box, got %+v", entries)
+	}
+
+	// The box holds the centroid of the farm without a boundary
+	entries, err = repo.GetMapEntries(models.DashboardFilter{}, &geo.BBox{MinLon: -56, MinLat: -12, MaxLon: -55, MaxLat: -11})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != located.ID {
+		t.Errorf("Expected the farm whose centroid lies in the box, got %+v", entries)
+	}
+}
+
+func TestFarmRepository_GetMapEntry(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmRepository(db)
+
+	farm := createFarm(t, db, "Fazenda Sem Mapa", "MT")
+	createHarvest(t, db, farm, "Soja", 2024, 100)
+
+	// Unlike the map, a single entry is returned with or without a boundary
+	entry, err := repo.GetMapEntry(farm.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entry.ID != farm.ID || entry.Boundary != nil || entry.LatestCulture != "Soja" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+
+	if _, err := repo.GetMapEntry(farm.ID + 1000); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFarmRepository_GetBoundaries(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmRepository(db)
+
+	setBoundary := func(farm *models.Farm, ring geo.Ring) {
+		t.Helper()
+		farm.Boundary = geo.NewPolygon(ring)
+		farm.MeasureBoundary()
+		if _, err := repo.Update(farm); err != nil {
+			t.Fatalf("Failed to set boundary: %v", err)
+		}
+	}
+
+	west := createFarm(t, db, "Fazenda Oeste", "MT")
+	setBoundary(west, geo.Ring{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.54}, {-55.7, -12.55}})
+	east := createFarm(t, db, "Fazenda Leste", "MT")
+	setBoundary(east, geo.Ring{{-55.5, -12.55}, {-55.49, -12.55}, {-55.49, -12.54}, {-55.5, -12.54}, {-55.5, -12.55}})
+	createFarm(t, db, "Fazenda Sem Mapa", "MT")
+
+	boundaries, err := repo.GetBoundaries(nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(boundaries) != 2 || boundaries[0].ID != west.ID || boundaries[1].ID != east.ID {
+		t.Fatalf("Expected the two farms with a boundary, got %+v", boundaries)
+	}
+	if boundaries[0].Name != "Fazenda Oeste" || boundaries[0].Boundary == nil || boundaries[0].BoundaryArea == nil {
+		t.Errorf("Unexpected boundary: %+v", boundaries[0])
+	}
+
+	// only the bounding box of the western farm reaches into the box
+	box := geo.BBox{MinLon: -55.695, MinLat: -12.6, MaxLon: -55.6, MaxLat: -12.5}
+	boundaries, err = repo.GetBoundaries(&box, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(boundaries) != 1 || boundaries[0].ID != west.ID {
+		t.Errorf("Expected only the western farm in the box, got %+v", boundaries)
+	}
+
+	boundaries, err = repo.GetBoundaries(&box, west.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(boundaries) != 0 {
+		t.Errorf("Expected the excluded farm to be left out, got %+v", boundaries)
+	}
+}
+
+func TestFarmRepository_GetNearby(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmRepository(db)
+
+	locate := func(farm *models.Farm, lat, lon float64) {
+		t.Helper()
+		farm.CentroidLat, farm.CentroidLon = &lat, &lon
+		if _, err := repo.Update(farm); err != nil {
+			t.Fatalf("Failed to set centroid: %v", err)
+		}
+	}
+
+	sorriso := createFarm(t, db, "Fazenda Sorriso", "MT")
+	locate(sorriso, -12.55, -55.7)
+	createHarvest(t, db, sorriso, "Soja", 2024, 100)
+	sinop := createFarm(t, db, "Fazenda Sinop", "MT")
+	locate(sinop, -11.86, -55.5)
+	createHarvest(t, db, sinop, "Milho", 2024, 100)
+	createFarm(t, db, "Fazenda Sem Local", "MT")
+
+	center := geo.Position{-55.7, -12.55}
+	firstPage := models.PaginationParams{Page: 1, Limit: 10}
+
+	nearby, total, err := repo.GetNearby(center, 100000, models.DashboardFilter{}, firstPage)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 2 || len(nearby) != 2 || nearby[0].Farm.ID != sorriso.ID || nearby[1].Farm.ID != sinop.ID {
+		t.Fatalf("Expected the two located farms, nearest first, got %d: %+v", total, nearby)
+	}
+	expected := geo.Distance(center, geo.Position{-55.5, -11.86}) / 1000
+	if nearby[0].Distance != 0 || math.Abs(nearby[1].Distance-expected) > 0.001 {
+		t.Errorf("Expected distances 0 and %.3f km, got %v and %v", expected, nearby[0].Distance, nearby[1].Distance)
+	}
+	if len(nearby[1].Farm.Harvests) != 1 {
+		t.Errorf("Expected the farm with its harvests, got %+v", nearby[1].Farm)
+	}
+
+	// The page is cut from the sorted farms, and the total counts them all
+	nearby, total, err = repo.GetNearby(center, 100000, models.DashboardFilter{}, models.PaginationParams{Page: 2, Limit: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 2 || len(nearby) != 1 || nearby[0].Farm.ID != sinop.ID {
+		t.Errorf("Expected the farther farm on the second page, got %d: %+v", total, nearby)
+	}
+
+	nearby, total, err = repo.GetNearby(center, 100000, models.DashboardFilter{Culture: "milho", CultureID: cultureID(t, db, "milho")}, firstPage)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 1 || len(nearby) != 1 || nearby[0].Farm.ID != sinop.ID {
+		t.Errorf("Expected only the farm that harvested the culture, got %+v", nearby)
+	}
+
+	nearby, total, err = repo.GetNearby(center, 50000, models.DashboardFilter{}, firstPage)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if total != 1 || len(nearby) != 1 || nearby[0].Farm.ID != sorriso.ID {
+		t.Errorf("Expected only the farm within the radius, got %+v", nearby)
+	}
+}
+
+func TestFarmRepository_GetByIDs(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmRepository(db)
+
+	first := createFarm(t, db, "Fazenda A", "MT")
+	second := createFarm(t, db, "Fazenda B", "MT")
+	createHarvest(t, db, second, "Soja", 2024, 100)
+
+	farms, err := repo.GetByIDs([]uint{second.ID, first.ID, second.ID + 1000})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(farms) != 2 || farms[0].ID != first.ID || farms[1].ID != second.ID {
+		t.Fatalf("Expected the two existing farms in ID order, got %+v", farms)
+	}
+	if len(farms[1].Harvests) != 1 {
+		t.Errorf("Expected the harvests to be loaded, got %+v", farms[1].Harvests)
+	}
+
+	if farms, err := repo.GetByIDs(nil); err != nil || len(farms) != 0 {
+		t.Errorf("Expected no farms for no IDs, got %+v, %v", farms, err)
+	}
+}
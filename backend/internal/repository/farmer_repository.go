@@ -2,8 +2,16 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"github.com/samuel-prates/farm-project/backend/pkg/document"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FarmerRepository struct {
@@ -16,48 +24,230 @@ func NewFarmerRepository(db *gorm.DB) *FarmerRepository {
 
 func (r *FarmerRepository) Create(farmer *models.Farmer) (*models.Farmer, error) {
 	if err := r.db.Create(farmer).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, farmerMessages)
 	}
 	return farmer, nil
 }
 
+// Update writes the farmer columns and reconciles its farms and harvests by ID inside a
+// single transaction: existing rows are updated, new rows inserted and only the rows
+// missing from the payload are deleted. A nil Farms slice leaves the farms untouched.
+// farmer.Version must hold the version the caller read; the update fails with
+// ErrPreconditionFailed if the row has moved on since then.
 func (r *FarmerRepository) Update(farmer *models.Farmer) (*models.Farmer, error) {
-	if err := r.db.Where("farmer_id = ?", farmer.ID).Delete(&models.Farm{}).Error; err != nil {
-		return nil, err
-	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expected := farmer.Version
+		farmer.Version = expected + 1
+
+		result := tx.Model(farmer).
+			Where("version = ?", expected).
+			Select("*").
+			Omit("ID", "CreatedAt", "DeletedAt", clause.Associations).
+			Updates(farmer)
+		if err := staleIfNoRows(tx, result, &models.Farmer{}, farmer.ID, farmerMessages); err != nil {
+			return err
+		}
 
-	if err := r.db.Save(farmer).Error; err != nil {
-		return nil, err
+		if farmer.Farms == nil {
+			return nil
+		}
+		return reconcileFarms(tx, farmer.ID, farmer.Farms)
+	})
+	if err != nil {
+		return nil, translateError(err, farmerMessages)
 	}
-	return farmer, nil
+
+	return r.GetByID(farmer.ID)
 }
 
+// Delete soft-deletes the farmer together with its farms and their harvests. Every row
+// removed by the cascade shares the same deleted_at so Restore can bring them back.
 func (r *FarmerRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Farmer{}, id).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		deletedAt := time.Now()
+
+		result := tx.Model(&models.Farmer{}).Where("id = ?", id).UpdateColumn("deleted_at", deletedAt)
+		if err := notFoundIfNoRows(result, farmerMessages); err != nil {
+			return err
+		}
+
+		var farmIDs []uint
+		if err := tx.Model(&models.Farm{}).Where("farmer_id = ?", id).Pluck("id", &farmIDs).Error; err != nil {
+			return err
+		}
+		return deleteFarms(tx, farmIDs, deletedAt)
+	})
+	return translateError(err, farmerMessages)
+}
+
+// Restore undoes a farmer deletion. Only the farms and harvests removed by the same
+// cascade are restored; rows deleted earlier on their own stay in the trash. It fails
+// with a conflict when an active farmer has taken the document in the meantime.
+func (r *FarmerRepository) Restore(id uint) (*models.Farmer, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var farmer models.Farmer
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&farmer, id).Error; err != nil {
+			return err
+		}
+		deletedAt := farmer.DeletedAt.Time
+
+		// The document may have been registered again since the farmer was deleted
+		var active int64
+		if err := tx.Model(&models.Farmer{}).
+			Where("federal_identification = ?", farmer.FederalIdentification).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return apperrors.Conflict("já existe um fazendeiro ativo com este documento")
+		}
+
+		var farmIDs []uint
+		if err := tx.Unscoped().Model(&models.Farm{}).
+			Where("farmer_id = ? AND deleted_at = ?", id, deletedAt).
+			Pluck("id", &farmIDs).Error; err != nil {
+			return err
+		}
+
+		if len(farmIDs) > 0 {
+			if err := tx.Unscoped().Model(&models.Harvest{}).
+				Where("farm_id IN ? AND deleted_at = ?", farmIDs, deletedAt).
+				UpdateColumn("deleted_at", nil).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Model(&models.Farm{}).
+				Where("id IN ?", farmIDs).
+				UpdateColumn("deleted_at", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Model(&farmer).UpdateColumn("deleted_at", nil).Error
+	})
+	if err != nil {
+		return nil, translateError(err, farmerMessages)
+	}
+
+	return r.GetByID(id)
 }
 
 func (r *FarmerRepository) GetByID(id uint) (*models.Farmer, error) {
 	var farmer models.Farmer
 	if err := r.db.Preload("Farms.Harvests").Preload("Farms").First(&farmer, id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, farmerMessages)
 	}
 	return &farmer, nil
 }
 
-func (r *FarmerRepository) GetAll(params models.PaginationParams) ([]models.Farmer, int64, error) {
+func (r *FarmerRepository) Exists(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Farmer{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, translateError(err, farmerMessages)
+	}
+	return count > 0, nil
+}
+
+func (r *FarmerRepository) GetAll(filter models.FarmerFilter, params models.PaginationParams) ([]models.Farmer, int64, error) {
 	var farmers []models.Farmer
 	var total int64
 
+	query := applyFarmerFilter(r.db.Model(&models.Farmer{}), filter)
+
 	// Count total records
-	if err := r.db.Model(&models.Farmer{}).Count(&total).Error; err != nil {
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, farmerMessages)
+	}
+
+	sorted, err := applySort(query, filter.Sort, farmerSortColumns, "farmers.id")
+	if err != nil {
 		return nil, 0, err
 	}
 
 	// Apply pagination
 	offset := (params.Page - 1) * params.Limit
-	if err := r.db.Offset(offset).Limit(params.Limit).Preload("Farms.Harvests").Preload("Farms").Find(&farmers).Error; err != nil {
-		return nil, 0, err
+	if err := sorted.Offset(offset).Limit(params.Limit).Preload("Farms.Harvests").Preload("Farms").Find(&farmers).Error; err != nil {
+		return nil, 0, translateError(err, farmerMessages)
 	}
 
 	return farmers, total, nil
 }
+
+// GetPage returns the farmers on the cursor's page in ID order, with the cursors of
+// the neighbouring pages. Sorting is not supported here, as the cursor only holds the ID.
+func (r *FarmerRepository) GetPage(filter models.FarmerFilter, c cursor.Cursor, limit int) ([]models.Farmer, *cursor.Cursor, *cursor.Cursor, error) {
+	if len(filter.Sort) > 0 {
+		var errs validation.Errors
+		errs.Add("sort", validation.CodeInvalid, "a ordenação não é suportada na paginação por cursor")
+		return nil, nil, nil, errs.Err()
+	}
+
+	var farmers []models.Farmer
+	query := applyKeyset(applyFarmerFilter(r.db.Model(&models.Farmer{}), filter), "farmers.id", c, limit)
+	if err := query.Preload("Farms.Harvests").Preload("Farms").Find(&farmers).Error; err != nil {
+		return nil, nil, nil, translateError(err, farmerMessages)
+	}
+
+	farmers, next, prev := keysetPage(farmers, c, limit, func(f models.Farmer) uint { return f.ID })
+	return farmers, next, prev, nil
+}
+
+// Count returns how many farmers match filter
+func (r *FarmerRepository) Count(filter models.FarmerFilter) (int64, error) {
+	var total int64
+	if err := applyFarmerFilter(r.db.Model(&models.Farmer{}), filter).Count(&total).Error; err != nil {
+		return 0, translateError(err, farmerMessages)
+	}
+	return total, nil
+}
+
+// farmerTotalArea sums the total area of the farmer's active farms
+const farmerTotalArea = "(SELECT COALESCE(SUM(farms.total_area), 0) FROM farms WHERE farms.farmer_id = farmers.id AND farms.deleted_at IS NULL)"
+
+// farmerSortColumns whitelists the fields the farmer list can be sorted by
+var farmerSortColumns = map[string]string{
+	"id":                    "farmers.id",
+	"farmerName":            "farmers.name",
+	"federalIdentification": "farmers.federal_identification",
+	"document":              "farmers.federal_identification",
+	"totalArea":             farmerTotalArea,
+	"createdAt":             "farmers.created_at",
+	"updatedAt":             "farmers.updated_at",
+}
+
+// applyFarmerFilter adds one condition per filled filter field. Farm and harvest filters
+// match farmers that own at least one active farm (or harvest) meeting the condition.
+func applyFarmerFilter(query *gorm.DB, filter models.FarmerFilter) *gorm.DB {
+	if filter.Name != "" {
+		query = query.Where(`LOWER(farmers.name) LIKE ? ESCAPE '\'`, containsPattern(filter.Name))
+	}
+	if digits := document.Normalize(filter.Document); digits != "" {
+		query = query.Where("farmers.federal_identification LIKE ?", digits+"%")
+	}
+	if filter.State != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM farms WHERE farms.farmer_id = farmers.id AND farms.deleted_at IS NULL AND UPPER(farms.state) = ?)",
+			strings.ToUpper(filter.State),
+		)
+	}
+	if filter.City != "" {
+		query = query.Where(
+			`EXISTS (SELECT 1 FROM farms WHERE farms.farmer_id = farmers.id AND farms.deleted_at IS NULL AND LOWER(farms.city) LIKE ? ESCAPE '\')`,
+			containsPattern(filter.City),
+		)
+	}
+	if filter.CultureID != nil {
+		query = query.Where(
+			`EXISTS (SELECT 1 FROM harvests JOIN farms ON farms.id = harvests.farm_id
+				WHERE farms.farmer_id = farmers.id AND farms.deleted_at IS NULL AND harvests.deleted_at IS NULL
+				AND harvests.culture_id = ?)`,
+			*filter.CultureID,
+		)
+	}
+	if filter.MinArea != nil {
+		query = query.Where(farmerTotalArea+" >= ?", *filter.MinArea)
+	}
+	if filter.MaxArea != nil {
+		query = query.Where(farmerTotalArea+" <= ?", *filter.MaxArea)
+	}
+	return query
+}
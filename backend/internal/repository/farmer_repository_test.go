@@ -0,0 +1,401 @@
+// internal/repository/farmer_repository_test.go
+package repository_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"gorm.io/gorm"
+)
+
+// createFarmer saves a farmer owning the given farms, each with its harvests linked to
+// the catalog
+func createFarmer(t *testing.T, db *gorm.DB, name, document string, farms ...models.Farm) *models.Farmer {
+	t.Helper()
+
+	for _, farm := range farms {
+		for i := range farm.Harvests {
+			farm.Harvests[i].CultureID = cultureID(t, db, farm.Harvests[i].Culture)
+		}
+	}
+
+	farmer, err := repository.NewFarmerRepository(db).Create(&models.Farmer{
+		FarmerName: name, FederalIdentification: document, Farms: farms,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create farmer: %v", err)
+	}
+	return farmer
+}
+
+func newFarm(name string, harvests ...models.Harvest) models.Farm {
+	return models.Farm{
+		Name: name, City: "Sorriso", State: "MT",
+		TotalArea: 1000, AgricultureArea: 800, VegetationArea: 200,
+		Harvests: harvests,
+	}
+}
+
+func newHarvest(culture string, year int, plantedArea float64) models.Harvest {
+	return models.Harvest{Year: year, Culture: culture, PlantedArea: plantedArea}
+}
+
+// stored reloads a farm or harvest, including a soft-deleted one
+func stored[T any](t *testing.T, db *gorm.DB, id uint) T {
+	t.Helper()
+
+	var row T
+	if err := db.Unscoped().First(&row, id).Error; err != nil {
+		t.Fatalf("Failed to load %T %d: %v", row, id, err)
+	}
+	return row
+}
+
+func TestFarmerRepository_Update_Reconcile(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmerRepository(db)
+
+	farmer := createFarmer(t, db, "João da Silva", "52998224725",
+		newFarm("Fazenda Boa Vista", newHarvest("Soja", 2023, 100), newHarvest("Milho", 2023, 100)),
+		newFarm("Fazenda Santa Rita", newHarvest("Soja", 2024, 100)),
+	)
+	kept, dropped := farmer.Farms[0], farmer.Farms[1]
+	keptHarvest, droppedHarvest := kept.Harvests[0], kept.Harvests[1]
+
+	// The first farm and its first harvest are edited, a harvest and a farm are added
+	// and the rest is left out
+	edited := kept
+	edited.Name = "Fazenda Boa Vista II"
+	editedHarvest := keptHarvest
+	editedHarvest.PlantedArea = 150
+	edited.Harvests = []models.Harvest{editedHarvest, newHarvest("Trigo", 2024, 50)}
+	farmer.Farms = []models.Farm{edited, newFarm("Fazenda Nova")}
+
+	updated, err := repo.Update(farmer)
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	if len(updated.Farms) != 2 {
+		t.Fatalf("Expected 2 farms, got %+v", updated.Farms)
+	}
+	farm := stored[models.Farm](t, db, kept.ID)
+	if farm.Name != "Fazenda Boa Vista II" || farm.Version != kept.Version+1 || farm.DeletedAt.Valid {
+		t.Errorf("Expected the farm to be updated in place, got %+v", farm)
+	}
+	harvest := stored[models.Harvest](t, db, keptHarvest.ID)
+	if harvest.PlantedArea != 150 || harvest.Version != keptHarvest.Version+1 || harvest.DeletedAt.Valid {
+		t.Errorf("Expected the harvest to be updated in place, got %+v", harvest)
+	}
+	if harvest := stored[models.Harvest](t, db, droppedHarvest.ID); !harvest.DeletedAt.Valid {
+		t.Errorf("Expected the harvest left out to be deleted, got %+v", harvest)
+	}
+
+	var added []models.Harvest
+	if err := db.Where("farm_id = ? AND culture = ?", kept.ID, "Trigo").Find(&added).Error; err != nil || len(added) != 1 {
+		t.Errorf("Expected the new harvest to be inserted, got %+v (%v)", added, err)
+	}
+	var inserted []models.Farm
+	if err := db.Where("farmer_id = ? AND name = ?", farmer.ID, "Fazenda Nova").Find(&inserted).Error; err != nil || len(inserted) != 1 {
+		t.Errorf("Expected the new farm to be inserted, got %+v (%v)", inserted, err)
+	}
+
+	// The farm left out goes to the trash with its harvests, in the same cascade
+	farm = stored[models.Farm](t, db, dropped.ID)
+	harvest = stored[models.Harvest](t, db, dropped.Harvests[0].ID)
+	if !farm.DeletedAt.Valid || !harvest.DeletedAt.Valid || !farm.DeletedAt.Time.Equal(harvest.DeletedAt.Time) {
+		t.Errorf("Expected the farm left out and its harvest to be deleted together, got %v and %v", farm.DeletedAt, harvest.DeletedAt)
+	}
+}
+
+func TestFarmerRepository_Update_LeaveAloneOrDeleteAll(t *testing.T) {
+	tests := []struct {
+		name         string
+		farms        func(stored []models.Farm) []models.Farm
+		farmsLeft    int
+		harvestsLeft int
+	}{
+		{
+			name:         "Nil Farms Leave Them Alone",
+			farms:        func([]models.Farm) []models.Farm { return nil },
+			farmsLeft:    1,
+			harvestsLeft: 2,
+		},
+		{
+			name:         "Empty Farms Delete Them All",
+			farms:        func([]models.Farm) []models.Farm { return []models.Farm{} },
+			farmsLeft:    0,
+			harvestsLeft: 0,
+		},
+		{
+			name: "Nil Harvests Leave Them Alone",
+			farms: func(stored []models.Farm) []models.Farm {
+				farm := stored[0]
+				farm.Harvests = nil
+				return []models.Farm{farm}
+			},
+			farmsLeft:    1,
+			harvestsLeft: 2,
+		},
+		{
+			name: "Empty Harvests Delete Them All",
+			farms: func(stored []models.Farm) []models.Farm {
+				farm := stored[0]
+				farm.Harvests = []models.Harvest{}
+				return []models.Farm{farm}
+			},
+			farmsLeft:    1,
+			harvestsLeft: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testDB(t)
+			repo := repository.NewFarmerRepository(db)
+
+			farmer := createFarmer(t, db, "João da Silva", "52998224725",
+				newFarm("Fazenda Boa Vista", newHarvest("Soja", 2023, 100), newHarvest("Milho", 2024, 100)),
+			)
+			farmer.Farms = tt.farms(farmer.Farms)
+
+			updated, err := repo.Update(farmer)
+			if err != nil {
+				t.Fatalf("Update returned error: %v", err)
+			}
+
+			harvests := 0
+			for _, farm := range updated.Farms {
+				harvests += len(farm.Harvests)
+			}
+			if len(updated.Farms) != tt.farmsLeft || harvests != tt.harvestsLeft {
+				t.Errorf("Expected %d farms and %d harvests, got %d and %d", tt.farmsLeft, tt.harvestsLeft, len(updated.Farms), harvests)
+			}
+		})
+	}
+}
+
+func TestFarmerRepository_Update_ForeignIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		farms func(own, foreign models.Farm) []models.Farm
+	}{
+		{
+			name:  "Farm Of Another Farmer",
+			farms: func(_, foreign models.Farm) []models.Farm { return []models.Farm{foreign} },
+		},
+		{
+			name: "Harvest Of Another Farm",
+			farms: func(own, foreign models.Farm) []models.Farm {
+				own.Harvests = foreign.Harvests
+				return []models.Farm{own}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testDB(t)
+			repo := repository.NewFarmerRepository(db)
+
+			other := createFarmer(t, db, "Maria Souza", "11144477735",
+				newFarm("Fazenda Alheia", newHarvest("Soja", 2024, 100)),
+			)
+			foreign := other.Farms[0]
+			farmer := createFarmer(t, db, "João da Silva", "52998224725", newFarm("Fazenda Boa Vista"))
+
+			farmer.Farms = tt.farms(farmer.Farms[0], foreign)
+			if _, err := repo.Update(farmer); !errors.Is(err, apperrors.ErrValidation) {
+				t.Fatalf("Expected a validation error, got %v", err)
+			}
+
+			// The rows of the other farmer are left as they were
+			if farm := stored[models.Farm](t, db, foreign.ID); farm.FarmerID == nil || *farm.FarmerID != other.ID || farm.Version != foreign.Version {
+				t.Errorf("Expected the foreign farm to be untouched, got %+v", farm)
+			}
+			if harvest := stored[models.Harvest](t, db, foreign.Harvests[0].ID); harvest.FarmID == nil || *harvest.FarmID != foreign.ID || harvest.Version != foreign.Harvests[0].Version {
+				t.Errorf("Expected the foreign harvest to be untouched, got %+v", harvest)
+			}
+		})
+	}
+}
+
+func TestFarmerRepository_Update_ConcurrentFarmEdit(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmerRepository(db)
+
+	farmer := createFarmer(t, db, "João da Silva", "52998224725", newFarm("Fazenda Boa Vista"))
+	farm := farmer.Farms[0]
+
+	// Another request saves the farm right before the reconciliation writes it
+	edited := false
+	if err := db.Callback().Update().Before("gorm:update").Register("test:concurrent_edit", func(tx *gorm.DB) {
+		if tx.Statement.Table != "farms" || edited {
+			return
+		}
+		edited = true
+		tx.Session(&gorm.Session{NewDB: true}).Exec("UPDATE farms SET name = ?, version = version + 1 WHERE id = ?", "Editada", farm.ID)
+	}); err != nil {
+		t.Fatalf("Failed to register callback: %v", err)
+	}
+
+	farmer.Farms[0].Name = "Fazenda Boa Vista II"
+	_, err := repo.Update(farmer)
+	if !errors.Is(err, apperrors.ErrPreconditionFailed) {
+		t.Fatalf("Expected ErrPreconditionFailed, got %v", err)
+	}
+
+	// Nothing the failed update wrote is kept
+	if saved := stored[models.Farm](t, db, farm.ID); saved.Name != farm.Name {
+		t.Errorf("Expected the farm name to stay %q, got %q", farm.Name, saved.Name)
+	}
+}
+
+func TestFarmerRepository_DeleteAndRestore(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmerRepository(db)
+
+	farmer := createFarmer(t, db, "João da Silva", "52998224725",
+		newFarm("Fazenda Boa Vista", newHarvest("Soja", 2024, 100)),
+		newFarm("Fazenda Santa Rita", newHarvest("Milho", 2024, 100)),
+	)
+	cascaded, earlier := farmer.Farms[0], farmer.Farms[1]
+
+	// A farm deleted on its own before the farmer stays in the trash on restore
+	if err := repository.NewFarmRepository(db).Delete(earlier.ID); err != nil {
+		t.Fatalf("Failed to delete farm: %v", err)
+	}
+	if err := repo.Delete(farmer.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	deleted := stored[models.Farmer](t, db, farmer.ID)
+	farm := stored[models.Farm](t, db, cascaded.ID)
+	harvest := stored[models.Harvest](t, db, cascaded.Harvests[0].ID)
+	if !deleted.DeletedAt.Valid || !farm.DeletedAt.Time.Equal(deleted.DeletedAt.Time) || !harvest.DeletedAt.Time.Equal(deleted.DeletedAt.Time) {
+		t.Fatalf("Expected the cascade to share deleted_at, got %v, %v and %v", deleted.DeletedAt, farm.DeletedAt, harvest.DeletedAt)
+	}
+	if _, err := repo.GetByID(farmer.ID); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("Expected a deleted farmer to be hidden, got %v", err)
+	}
+
+	restored, err := repo.Restore(farmer.ID)
+	if err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	if len(restored.Farms) != 1 || restored.Farms[0].ID != cascaded.ID || len(restored.Farms[0].Harvests) != 1 {
+		t.Errorf("Expected only the farm deleted with the farmer back, got %+v", restored.Farms)
+	}
+	if farm := stored[models.Farm](t, db, earlier.ID); !farm.DeletedAt.Valid {
+		t.Errorf("Expected the farm deleted earlier to stay in the trash, got %+v", farm)
+	}
+	if harvest := stored[models.Harvest](t, db, earlier.Harvests[0].ID); !harvest.DeletedAt.Valid {
+		t.Errorf("Expected the harvest deleted earlier to stay in the trash, got %+v", harvest)
+	}
+
+	if _, err := repo.Restore(farmer.ID); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("Expected restoring an active farmer to fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestFarmerRepository_DocumentOfDeletedFarmer(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmerRepository(db)
+
+	deleted := createFarmer(t, db, "João da Silva", "52998224725")
+
+	// The document is still taken while the farmer is active
+	_, err := repo.Create(&models.Farmer{FarmerName: "João Silva", FederalIdentification: "52998224725"})
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("Expected a conflict for an active farmer's document, got %v", err)
+	}
+
+	if err := repo.Delete(deleted.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	createFarmer(t, db, "João Silva", "52998224725")
+
+	// Restoring would leave two active farmers with the same document
+	if _, err := repo.Restore(deleted.ID); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("Expected a conflict when the document was registered again, got %v", err)
+	}
+	if farmer := stored[models.Farmer](t, db, deleted.ID); !farmer.DeletedAt.Valid {
+		t.Errorf("Expected the farmer to stay in the trash, got %+v", farmer)
+	}
+}
+
+func TestFarmerRepository_GetAll(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewFarmerRepository(db)
+
+	large := newFarm("Fazenda Boa Vista", newHarvest("Soja", 2024, 100))
+	small := newFarm("Fazenda Santa Rita", newHarvest("Milho", 2024, 100))
+	small.City, small.State, small.TotalArea, small.AgricultureArea, small.VegetationArea = "Rio Verde", "GO", 300, 200, 100
+
+	joao := createFarmer(t, db, "João da Silva", "52998224725", large)
+	maria := createFarmer(t, db, "Maria 100% Souza", "11144477735", small, newFarm("Fazenda Encerrada", newHarvest("Soja", 2024, 100)))
+	ana := createFarmer(t, db, "Ana_Paula", "39053344705")
+
+	// A farm in the trash matches no filter
+	if err := repository.NewFarmRepository(db).Delete(maria.Farms[1].ID); err != nil {
+		t.Fatalf("Failed to delete farm: %v", err)
+	}
+
+	minArea, maxArea := 500.0, 500.0
+
+	tests := []struct {
+		name     string
+		filter   models.FarmerFilter
+		expected []uint
+	}{
+		{name: "No Filters", expected: []uint{joao.ID, maria.ID, ana.ID}},
+		{name: "Name Percent Is Literal", filter: models.FarmerFilter{Name: "%"}, expected: []uint{maria.ID}},
+		{name: "Name Underscore Is Literal", filter: models.FarmerFilter{Name: "_"}, expected: []uint{ana.ID}},
+		{name: "Name Ignores Case", filter: models.FarmerFilter{Name: "JOÃO"}, expected: []uint{joao.ID}},
+		{name: "Document Prefix", filter: models.FarmerFilter{Document: "529.982"}, expected: []uint{joao.ID}},
+		{name: "State", filter: models.FarmerFilter{State: "mt"}, expected: []uint{joao.ID}},
+		{name: "City", filter: models.FarmerFilter{City: "rio"}, expected: []uint{maria.ID}},
+		{name: "Culture", filter: models.FarmerFilter{Culture: "soja", CultureID: cultureID(t, db, "soja")}, expected: []uint{joao.ID}},
+		{name: "Culture Alias", filter: models.FarmerFilter{Culture: "soybean", CultureID: cultureID(t, db, "soybean")}, expected: []uint{joao.ID}},
+		{name: "Min Area", filter: models.FarmerFilter{MinArea: &minArea}, expected: []uint{joao.ID}},
+		{name: "Max Area", filter: models.FarmerFilter{MaxArea: &maxArea}, expected: []uint{maria.ID, ana.ID}},
+		{
+			name:     "Sort By Total Area",
+			filter:   models.FarmerFilter{Sort: []models.SortField{{Field: "totalArea", Desc: true}}},
+			expected: []uint{joao.ID, maria.ID, ana.ID},
+		},
+		{
+			name:     "Sort By Name",
+			filter:   models.FarmerFilter{Sort: []models.SortField{{Field: "farmerName"}}},
+			expected: []uint{ana.ID, joao.ID, maria.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			farmers, total, err := repo.GetAll(tt.filter, models.PaginationParams{Page: 1, Limit: 10})
+			if err != nil {
+				t.Fatalf("GetAll returned error: %v", err)
+			}
+
+			ids := make([]uint, len(farmers))
+			for i, farmer := range farmers {
+				ids[i] = farmer.ID
+			}
+			if !reflect.DeepEqual(ids, tt.expected) || total != int64(len(tt.expected)) {
+				t.Errorf("Expected farmers %v, got %v (total %d)", tt.expected, ids, total)
+			}
+		})
+	}
+
+	t.Run("Sort By Unknown Field", func(t *testing.T) {
+		filter := models.FarmerFilter{Sort: []models.SortField{{Field: "password"}}}
+		if _, _, err := repo.GetAll(filter, models.PaginationParams{Page: 1, Limit: 10}); !errors.Is(err, apperrors.ErrValidation) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+	})
+}
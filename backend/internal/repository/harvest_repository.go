@@ -2,6 +2,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
 	"gorm.io/gorm"
 )
@@ -14,28 +16,43 @@ func NewHarvestRepository(db *gorm.DB) *HarvestRepository {
 	return &HarvestRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries are bound to ctx
+func (r *HarvestRepository) WithContext(ctx context.Context) *HarvestRepository {
+	return &HarvestRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *HarvestRepository) Create(harvest *models.Harvest) (*models.Harvest, error) {
 	if err := r.db.Create(harvest).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, harvestMessages)
 	}
 	return harvest, nil
 }
 
+// Update writes the harvest columns. harvest.Version must hold the version the caller read.
 func (r *HarvestRepository) Update(harvest *models.Harvest) (*models.Harvest, error) {
-	if err := r.db.Save(harvest).Error; err != nil {
+	expected := harvest.Version
+	harvest.Version = expected + 1
+
+	result := r.db.Model(harvest).
+		Where("version = ?", expected).
+		Select("*").
+		Omit("ID", "CreatedAt", "DeletedAt").
+		Updates(harvest)
+	if err := staleIfNoRows(r.db, result, &models.Harvest{}, harvest.ID, harvestMessages); err != nil {
 		return nil, err
 	}
-	return harvest, nil
+
+	return r.GetByID(harvest.ID)
 }
 
 func (r *HarvestRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Harvest{}, id).Error
+	return notFoundIfNoRows(r.db.Delete(&models.Harvest{}, id), harvestMessages)
 }
 
 func (r *HarvestRepository) GetByID(id uint) (*models.Harvest, error) {
 	var harvest models.Harvest
 	if err := r.db.First(&harvest, id).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, harvestMessages)
 	}
 	return &harvest, nil
 }
@@ -43,19 +60,81 @@ func (r *HarvestRepository) GetByID(id uint) (*models.Harvest, error) {
 func (r *HarvestRepository) GetAll() ([]models.Harvest, error) {
 	var harvests []models.Harvest
 	if err := r.db.Find(&harvests).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, harvestMessages)
 	}
 	return harvests, nil
 }
 
-// Method for dashboard
-func (r *HarvestRepository) CountByType() ([]models.HarvestCultureCount, error) {
+func (r *HarvestRepository) GetAllByFarm(farmID uint, params models.PaginationParams) ([]models.Harvest, int64, error) {
+	var harvests []models.Harvest
+	var total int64
+
+	query := r.db.Model(&models.Harvest{}).Where("farm_id = ?", farmID)
+
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, harvestMessages)
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := query.Order("year DESC, id").Offset(offset).Limit(params.Limit).Find(&harvests).Error; err != nil {
+		return nil, 0, translateError(err, harvestMessages)
+	}
+
+	return harvests, total, nil
+}
+
+// CountByCulture counts the harvests, the distinct farms and the planted area of each
+// culture, per year as well when byYear is set
+func (r *HarvestRepository) CountByCulture(filter models.DashboardFilter, byYear bool) ([]models.HarvestCultureCount, error) {
+	columns := "harvests.culture"
+	if byYear {
+		columns += ", harvests.year"
+	}
+
 	var results []models.HarvestCultureCount
-	if err := r.db.Model(&models.Harvest{}).
-		Select("type, COUNT(*) as count").
-		Group("type").
+	if err := applyHarvestDashboardFilter(r.db.Model(&models.Harvest{}), filter).
+		Select(columns + ", COUNT(*) AS count, COUNT(DISTINCT harvests.farm_id) AS farms, " +
+			"COALESCE(SUM(harvests.planted_area), 0) AS planted_area").
+		Group(columns).
+		Order(columns).
 		Scan(&results).Error; err != nil {
-		return nil, err
+		return nil, translateError(err, harvestMessages)
+	}
+	return results, nil
+}
+
+// ProductionByCulture sums the planted area and the quantity produced per culture and
+// unit, leaving out the harvests with neither recorded
+func (r *HarvestRepository) ProductionByCulture(filter models.DashboardFilter) ([]models.CultureProduction, error) {
+	var results []models.CultureProduction
+	if err := applyHarvestDashboardFilter(r.db.Model(&models.Harvest{}), filter).
+		Select("harvests.culture, harvests.unit, COUNT(*) AS harvests, " +
+			"SUM(harvests.planted_area) AS planted_area, SUM(harvests.quantity) AS quantity").
+		Where("harvests.planted_area > 0 OR harvests.quantity > 0").
+		Group("harvests.culture, harvests.unit").
+		Order("harvests.culture, harvests.unit").
+		Scan(&results).Error; err != nil {
+		return nil, translateError(err, harvestMessages)
+	}
+	return results, nil
+}
+
+// ProductionBySeason is ProductionByCulture broken down by season. Harvests without a
+// season are left out.
+func (r *HarvestRepository) ProductionBySeason(filter models.DashboardFilter) ([]models.SeasonProduction, error) {
+	var results []models.SeasonProduction
+	if err := applyHarvestDashboardFilter(r.db.Model(&models.Harvest{}), filter).
+		Joins("JOIN seasons ON seasons.id = harvests.season_id").
+		Select("seasons.id AS season_id, seasons.name AS season, seasons.cycle, " +
+			"harvests.culture, harvests.unit, COUNT(*) AS harvests, " +
+			"SUM(harvests.planted_area) AS planted_area, SUM(harvests.quantity) AS quantity").
+		Where("harvests.planted_area > 0 OR harvests.quantity > 0").
+		Group("seasons.id, seasons.name, seasons.cycle, seasons.start_date, harvests.culture, harvests.unit").
+		Order("seasons.start_date, seasons.id, harvests.culture, harvests.unit").
+		Scan(&results).Error; err != nil {
+		return nil, translateError(err, harvestMessages)
 	}
 	return results, nil
 }
@@ -0,0 +1,159 @@
+// internal/repository/harvest_repository_test.go
+package repository_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+func createFarm(t *testing.T, db *gorm.DB, name, state string) *models.Farm {
+	t.Helper()
+
+	farm, err := repository.NewFarmRepository(db).Create(&models.Farm{
+		Name: name, City: "Sorriso", State: state,
+		TotalArea: 1000, AgricultureArea: 800, VegetationArea: 200,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create farm: %v", err)
+	}
+	return farm
+}
+
+// cultureID returns the ID of the catalog culture named name or having it as an alias
+func cultureID(t *testing.T, db *gorm.DB, name string) *uint {
+	t.Helper()
+
+	culture, err := repository.NewCultureRepository(db).FindByNormalizedName(models.NormalizeCultureName(name))
+	if err != nil {
+		t.Fatalf("Failed to find culture %q: %v", name, err)
+	}
+	if culture == nil {
+		t.Fatalf("Culture %q is not in the catalog", name)
+	}
+	return &culture.ID
+}
+
+func createHarvest(t *testing.T, db *gorm.DB, farm *models.Farm, culture string, year int, plantedArea float64) *models.Harvest {
+	t.Helper()
+
+	harvest, err := repository.NewHarvestRepository(db).Create(&models.Harvest{
+		Year: year, Culture: culture, CultureID: cultureID(t, db, culture), PlantedArea: plantedArea, FarmID: &farm.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create harvest: %v", err)
+	}
+	return harvest
+}
+
+func TestHarvestRepository_CountByCulture(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewHarvestRepository(db)
+
+	mt := createFarm(t, db, "Fazenda Boa Vista", "MT")
+	goias := createFarm(t, db, "Fazenda Santa Rita", "GO")
+	closed := createFarm(t, db, "Fazenda Encerrada", "MT")
+
+	createHarvest(t, db, mt, "Soja", 2023, 100)
+	createHarvest(t, db, mt, "Soja", 2024, 200)
+	createHarvest(t, db, goias, "Soja", 2024, 300)
+	createHarvest(t, db, goias, "Milho", 2024, 50)
+
+	// Neither a harvest in the trash nor one of a farm in the trash is counted
+	trashed := createHarvest(t, db, mt, "Soja", 2024, 999)
+	if err := repo.Delete(trashed.ID); err != nil {
+		t.Fatalf("Failed to delete harvest: %v", err)
+	}
+	createHarvest(t, db, closed, "Milho", 2024, 999)
+	if err := db.Model(&models.Farm{}).Where("id = ?", closed.ID).UpdateColumn("deleted_at", time.Now()).Error; err != nil {
+		t.Fatalf("Failed to trash farm: %v", err)
+	}
+
+	y2023, y2024 := 2023, 2024
+
+	tests := []struct {
+		name     string
+		filter   models.DashboardFilter
+		byYear   bool
+		expected []models.HarvestCultureCount
+	}{
+		{
+			name: "Per Culture",
+			expected: []models.HarvestCultureCount{
+				{Culture: "Milho", Count: 1, Farms: 1, PlantedArea: 50},
+				{Culture: "Soja", Count: 3, Farms: 2, PlantedArea: 600},
+			},
+		},
+		{
+			name:   "Per Culture And Year",
+			byYear: true,
+			expected: []models.HarvestCultureCount{
+				{Culture: "Milho", Year: &y2024, Count: 1, Farms: 1, PlantedArea: 50},
+				{Culture: "Soja", Year: &y2023, Count: 1, Farms: 1, PlantedArea: 100},
+				{Culture: "Soja", Year: &y2024, Count: 2, Farms: 2, PlantedArea: 500},
+			},
+		},
+		{
+			name:   "Filtered By State",
+			filter: models.DashboardFilter{State: "mt"},
+			expected: []models.HarvestCultureCount{
+				{Culture: "Soja", Count: 2, Farms: 1, PlantedArea: 300},
+			},
+		},
+		{
+			name:     "Nothing Matches",
+			filter:   models.DashboardFilter{Culture: "Café", CultureID: cultureID(t, db, "Café")},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.CountByCulture(tt.filter, tt.byYear)
+			if err != nil {
+				t.Fatalf("CountByCulture returned error: %v", err)
+			}
+			if len(got) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("CountByCulture() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHarvestRepository_ProductionByCulture(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewHarvestRepository(db)
+
+	farm := createFarm(t, db, "Fazenda Boa Vista", "MT")
+	for _, harvest := range []models.Harvest{
+		{Year: 2024, Culture: "Soja", PlantedArea: 100, Quantity: 6000, Unit: models.UnitSacas},
+		{Year: 2023, Culture: "Soja", PlantedArea: 100, Quantity: 5000, Unit: models.UnitSacas},
+		{Year: 2024, Culture: "Soja", PlantedArea: 10, Quantity: 30, Unit: models.UnitToneladas},
+		{Year: 2024, Culture: "Milho"},
+	} {
+		harvest.FarmID = &farm.ID
+		if _, err := repo.Create(&harvest); err != nil {
+			t.Fatalf("Failed to create harvest: %v", err)
+		}
+	}
+
+	got, err := repo.ProductionByCulture(models.DashboardFilter{})
+	if err != nil {
+		t.Fatalf("ProductionByCulture returned error: %v", err)
+	}
+
+	expected := []models.CultureProduction{
+		{Culture: "Soja", Unit: models.UnitSacas, Harvests: 2, PlantedArea: 200, Quantity: 11000},
+		{Culture: "Soja", Unit: models.UnitToneladas, Harvests: 1, PlantedArea: 10, Quantity: 30},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ProductionByCulture() = %+v, want %+v", got, expected)
+	}
+}
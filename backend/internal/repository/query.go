@@ -0,0 +1,84 @@
+// internal/repository/query.go
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+	"gorm.io/gorm"
+)
+
+// applySort orders query by the requested fields. Only the fields whitelisted in columns
+// are accepted, mapped to their SQL expression; tieBreaker is always appended so that
+// pages are stable.
+func applySort(query *gorm.DB, sort []models.SortField, columns map[string]string, tieBreaker string) (*gorm.DB, error) {
+	var errs validation.Errors
+	for _, field := range sort {
+		column, ok := columns[field.Field]
+		if !ok {
+			errs.Add("sort", validation.CodeInvalid, fmt.Sprintf("não é possível ordenar por %q", field.Field))
+			continue
+		}
+		if field.Desc {
+			column += " DESC"
+		}
+		query = query.Order(column)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return query.Order(tieBreaker), nil
+}
+
+// containsPattern builds a case-insensitive LIKE pattern matching value anywhere,
+// escaping the LIKE wildcards typed by the user
+func containsPattern(value string) string {
+	return "%" + escapeLike(strings.ToLower(value)) + "%"
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// applyKeyset restricts query to the rows on the cursor's side of column, ordered
+// towards it, and fetches one row beyond limit so keysetPage can tell whether the
+// list goes on
+func applyKeyset(query *gorm.DB, column string, c cursor.Cursor, limit int) *gorm.DB {
+	if c.Backward {
+		return query.Where(column+" < ?", c.ID).Order(column + " DESC").Limit(limit + 1)
+	}
+	if c.ID > 0 {
+		query = query.Where(column+" > ?", c.ID)
+	}
+	return query.Order(column).Limit(limit + 1)
+}
+
+// keysetPage trims the rows fetched by applyKeyset to limit, puts them back in
+// ascending order and works out the cursors of the neighbouring pages
+func keysetPage[T any](rows []T, c cursor.Cursor, limit int, id func(T) uint) ([]T, *cursor.Cursor, *cursor.Cursor) {
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if c.Backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, nil, nil
+	}
+
+	var next, prev *cursor.Cursor
+	if more || c.Backward {
+		next = &cursor.Cursor{ID: id(rows[len(rows)-1])}
+	}
+	if (more && c.Backward) || (!c.Backward && c.ID > 0) {
+		prev = &cursor.Cursor{ID: id(rows[0]), Backward: true}
+	}
+	return rows, next, prev
+}
@@ -0,0 +1,141 @@
+// internal/repository/query_test.go
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestApplyKeyset(t *testing.T) {
+	// The statements are only built, never sent
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("Failed to open dry run database: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cursor       cursor.Cursor
+		expectedSQL  string
+		expectedVars []interface{}
+	}{
+		{
+			name:         "First Page",
+			expectedSQL:  `SELECT * FROM "farmers" WHERE "farmers"."deleted_at" IS NULL ORDER BY farmers.id LIMIT $1`,
+			expectedVars: []interface{}{3},
+		},
+		{
+			name:         "Forward",
+			cursor:       cursor.Cursor{ID: 7},
+			expectedSQL:  `SELECT * FROM "farmers" WHERE farmers.id > $1 AND "farmers"."deleted_at" IS NULL ORDER BY farmers.id LIMIT $2`,
+			expectedVars: []interface{}{uint(7), 3},
+		},
+		{
+			name:         "Backward",
+			cursor:       cursor.Cursor{ID: 7, Backward: true},
+			expectedSQL:  `SELECT * FROM "farmers" WHERE farmers.id < $1 AND "farmers"."deleted_at" IS NULL ORDER BY farmers.id DESC LIMIT $2`,
+			expectedVars: []interface{}{uint(7), 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var farmers []models.Farmer
+			stmt := applyKeyset(db.Model(&models.Farmer{}), "farmers.id", tt.cursor, 2).Find(&farmers).Statement
+
+			if sql := stmt.SQL.String(); sql != tt.expectedSQL {
+				t.Errorf("Expected %s, got %s", tt.expectedSQL, sql)
+			}
+			if !reflect.DeepEqual(stmt.Vars, tt.expectedVars) {
+				t.Errorf("Expected vars %v, got %v", tt.expectedVars, stmt.Vars)
+			}
+		})
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	// The rows are their own IDs, in the order applyKeyset fetches them with a limit of 2
+	tests := []struct {
+		name         string
+		rows         []uint
+		cursor       cursor.Cursor
+		expectedRows []uint
+		expectedNext *cursor.Cursor
+		expectedPrev *cursor.Cursor
+	}{
+		{
+			name:         "First Page With More",
+			rows:         []uint{1, 2, 3},
+			expectedRows: []uint{1, 2},
+			expectedNext: &cursor.Cursor{ID: 2},
+		},
+		{
+			name:         "Only Page",
+			rows:         []uint{1, 2},
+			expectedRows: []uint{1, 2},
+		},
+		{
+			name:         "Middle Page Forward",
+			rows:         []uint{3, 4, 5},
+			cursor:       cursor.Cursor{ID: 2},
+			expectedRows: []uint{3, 4},
+			expectedNext: &cursor.Cursor{ID: 4},
+			expectedPrev: &cursor.Cursor{ID: 3, Backward: true},
+		},
+		{
+			name:         "Last Page Forward",
+			rows:         []uint{5},
+			cursor:       cursor.Cursor{ID: 4},
+			expectedRows: []uint{5},
+			expectedPrev: &cursor.Cursor{ID: 5, Backward: true},
+		},
+		{
+			name:         "Middle Page Backward",
+			rows:         []uint{4, 3, 2},
+			cursor:       cursor.Cursor{ID: 5, Backward: true},
+			expectedRows: []uint{3, 4},
+			expectedNext: &cursor.Cursor{ID: 4},
+			expectedPrev: &cursor.Cursor{ID: 3, Backward: true},
+		},
+		{
+			name:         "First Page Backward",
+			rows:         []uint{2, 1},
+			cursor:       cursor.Cursor{ID: 3, Backward: true},
+			expectedRows: []uint{1, 2},
+			expectedNext: &cursor.Cursor{ID: 2},
+		},
+		{
+			name:         "Past The End",
+			rows:         []uint{},
+			cursor:       cursor.Cursor{ID: 9},
+			expectedRows: []uint{},
+		},
+		{
+			name:         "Before The Start",
+			rows:         []uint{},
+			cursor:       cursor.Cursor{ID: 1, Backward: true},
+			expectedRows: []uint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, next, prev := keysetPage(tt.rows, tt.cursor, 2, func(id uint) uint { return id })
+
+			if !reflect.DeepEqual(rows, tt.expectedRows) {
+				t.Errorf("Expected rows %v, got %v", tt.expectedRows, rows)
+			}
+			if !reflect.DeepEqual(next, tt.expectedNext) {
+				t.Errorf("Expected next %+v, got %+v", tt.expectedNext, next)
+			}
+			if !reflect.DeepEqual(prev, tt.expectedPrev) {
+				t.Errorf("Expected prev %+v, got %+v", tt.expectedPrev, prev)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// internal/repository/reconcile.go
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reconcileFarms makes the farms of a farmer match the incoming list. Farms with an ID
+// are updated in place, farms without one are inserted and the farmer's farms that are
+// not in the list are deleted together with their harvests. A farm or harvest changed
+// by another request since it was read here fails with ErrPreconditionFailed. Must run
+// inside a transaction.
+func reconcileFarms(tx *gorm.DB, farmerID uint, incoming []models.Farm) error {
+	var existing []models.Farm
+	if err := tx.Where("farmer_id = ?", farmerID).Find(&existing).Error; err != nil {
+		return err
+	}
+
+	plan := planReconcile(idsOf(existing, farmKey), idsOf(incoming, farmKey))
+	if plan.foreign != 0 {
+		return apperrors.Validation(fmt.Sprintf("fazenda %d não pertence a este fazendeiro", plan.foreign))
+	}
+
+	// versions maps the farmer's farm IDs to their stored version
+	versions := make(map[uint]uint, len(existing))
+	for _, farm := range existing {
+		versions[farm.ID] = farm.Version
+	}
+
+	for _, i := range plan.create {
+		incoming[i].FarmerID = &farmerID
+		if err := tx.Create(&incoming[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, i := range plan.update {
+		farm := &incoming[i]
+		farm.FarmerID = &farmerID
+		version := versions[farm.ID]
+		farm.Version = version + 1
+
+		result := tx.Model(farm).
+			Where("version = ?", version).
+			Select("*").
+			Omit("ID", "FarmerID", "CreatedAt", "DeletedAt", clause.Associations).
+			Updates(farm)
+		if err := staleIfNoRows(tx, result, &models.Farm{}, farm.ID, farmMessages); err != nil {
+			return err
+		}
+
+		if farm.Harvests != nil {
+			if err := reconcileHarvests(tx, farm.ID, farm.Harvests); err != nil {
+				return err
+			}
+		}
+	}
+
+	return deleteFarms(tx, plan.remove, time.Now())
+}
+
+// reconcileHarvests makes the harvests of a farm match the incoming list, following the
+// same rules as reconcileFarms. Must run inside a transaction.
+func reconcileHarvests(tx *gorm.DB, farmID uint, incoming []models.Harvest) error {
+	var existing []models.Harvest
+	if err := tx.Where("farm_id = ?", farmID).Find(&existing).Error; err != nil {
+		return err
+	}
+
+	plan := planReconcile(idsOf(existing, harvestKey), idsOf(incoming, harvestKey))
+	if plan.foreign != 0 {
+		return apperrors.Validation(fmt.Sprintf("safra %d não pertence a esta fazenda", plan.foreign))
+	}
+
+	// versions maps the farm's harvest IDs to their stored version
+	versions := make(map[uint]uint, len(existing))
+	for _, harvest := range existing {
+		versions[harvest.ID] = harvest.Version
+	}
+
+	for _, i := range plan.create {
+		incoming[i].FarmID = &farmID
+		if err := tx.Create(&incoming[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, i := range plan.update {
+		harvest := &incoming[i]
+		harvest.FarmID = &farmID
+		version := versions[harvest.ID]
+		harvest.Version = version + 1
+
+		result := tx.Model(harvest).
+			Where("version = ?", version).
+			Select("*").
+			Omit("ID", "FarmID", "CreatedAt", "DeletedAt").
+			Updates(harvest)
+		if err := staleIfNoRows(tx, result, &models.Harvest{}, harvest.ID, harvestMessages); err != nil {
+			return err
+		}
+	}
+
+	if len(plan.remove) == 0 {
+		return nil
+	}
+	return tx.Delete(&models.Harvest{}, plan.remove).Error
+}
+
+// deleteFarms soft-deletes the given farms and their harvests, stamping all of them
+// with deletedAt
+func deleteFarms(tx *gorm.DB, ids []uint, deletedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.Model(&models.Harvest{}).Where("farm_id IN ?", ids).UpdateColumn("deleted_at", deletedAt).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Farm{}).Where("id IN ?", ids).UpdateColumn("deleted_at", deletedAt).Error
+}
+
+// reconcilePlan tells how the stored rows are brought in line with an incoming list
+type reconcilePlan struct {
+	// create and update hold the positions of the incoming rows to insert and to update
+	create []int
+	update []int
+	// remove holds the stored IDs missing from the incoming list
+	remove []uint
+	// foreign is the first incoming ID that is not among the stored ones, or zero
+	foreign uint
+}
+
+// planReconcile matches the incoming IDs against the stored ones: rows without an ID
+// are created, rows with a stored ID updated and the stored rows left out removed. An
+// ID that is not stored belongs to another parent, or to no row at all; it is reported
+// in foreign and nothing else is planned.
+func planReconcile(stored, incoming []uint) reconcilePlan {
+	known := make(map[uint]bool, len(stored))
+	for _, id := range stored {
+		known[id] = true
+	}
+
+	var plan reconcilePlan
+	kept := make(map[uint]bool, len(incoming))
+	for i, id := range incoming {
+		switch {
+		case id == 0:
+			plan.create = append(plan.create, i)
+		case known[id]:
+			plan.update = append(plan.update, i)
+			kept[id] = true
+		default:
+			return reconcilePlan{foreign: id}
+		}
+	}
+
+	for _, id := range stored {
+		if !kept[id] {
+			plan.remove = append(plan.remove, id)
+		}
+	}
+	return plan
+}
+
+// idsOf lists the ID of each row, in order
+func idsOf[T any](rows []T, id func(T) uint) []uint {
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = id(row)
+	}
+	return ids
+}
+
+func farmKey(farm models.Farm) uint          { return farm.ID }
+func harvestKey(harvest models.Harvest) uint { return harvest.ID }
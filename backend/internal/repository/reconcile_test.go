@@ -0,0 +1,62 @@
+// internal/repository/reconcile_test.go
+package repository
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanReconcile(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   []uint
+		incoming []uint
+		expected reconcilePlan
+	}{
+		{
+			name:     "Nothing Stored",
+			incoming: []uint{0, 0},
+			expected: reconcilePlan{create: []int{0, 1}},
+		},
+		{
+			name:     "Missing IDs Are Created",
+			stored:   []uint{4},
+			incoming: []uint{0, 4, 0},
+			expected: reconcilePlan{create: []int{0, 2}, update: []int{1}},
+		},
+		{
+			name:     "Stored IDs Left Out Are Removed",
+			stored:   []uint{4, 5, 6},
+			incoming: []uint{5},
+			expected: reconcilePlan{update: []int{0}, remove: []uint{4, 6}},
+		},
+		{
+			name:     "Empty List Removes Everything",
+			stored:   []uint{4, 5},
+			incoming: []uint{},
+			expected: reconcilePlan{remove: []uint{4, 5}},
+		},
+		{
+			name:     "Unknown ID",
+			stored:   []uint{4},
+			incoming: []uint{4, 99},
+			expected: reconcilePlan{foreign: 99},
+		},
+		{
+			// The stored IDs are the parent's own, so a sibling's row is as foreign as
+			// one that does not exist
+			name:     "ID Of Another Parent",
+			stored:   []uint{4, 5},
+			incoming: []uint{0, 7, 4},
+			expected: reconcilePlan{foreign: 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if plan := planReconcile(tt.stored, tt.incoming); !reflect.DeepEqual(plan, tt.expected) {
+				t.Errorf("planReconcile(%v, %v) = %+v, want %+v", tt.stored, tt.incoming, plan, tt.expected)
+			}
+		})
+	}
+}
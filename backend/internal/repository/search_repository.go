@@ -0,0 +1,71 @@
+// internal/repository/search_repository.go
+package repository
+
+import (
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var searchMessages = entityMessages{
+	notFound: "nenhum resultado encontrado",
+	conflict: "conflito ao realizar a busca",
+}
+
+// searchQuery ranks active farmers and farms against a folded search term. A row
+// matches when the full-text prefix query does, when the term is similar to a word of
+// the name or city (pg_trgm), or, for farmers, when the document starts with the digits
+// typed. Relies on the extensions and f_unaccent created by database.Connect.
+const searchQuery = `
+WITH q AS (
+	SELECT ?::text AS term, to_tsquery('simple', ?) AS ts, ?::text AS digits
+)
+SELECT * FROM (
+	SELECT 'farmer' AS type, farmers.id, farmers.name, farmers.federal_identification AS document,
+		'' AS city, '' AS state, NULL::bigint AS parent_id,
+		GREATEST(
+			ts_rank(to_tsvector('simple', f_unaccent(lower(farmers.name))), q.ts),
+			word_similarity(q.term, f_unaccent(lower(farmers.name))),
+			CASE WHEN q.digits <> '' AND farmers.federal_identification LIKE q.digits || '%' THEN 1 ELSE 0 END
+		) AS rank
+	FROM farmers, q
+	WHERE farmers.deleted_at IS NULL AND (
+		to_tsvector('simple', f_unaccent(lower(farmers.name))) @@ q.ts
+		OR q.term <% f_unaccent(lower(farmers.name))
+		OR (q.digits <> '' AND farmers.federal_identification LIKE q.digits || '%')
+	)
+	UNION ALL
+	SELECT 'farm' AS type, farms.id, farms.name, '' AS document,
+		farms.city, farms.state, farms.farmer_id AS parent_id,
+		GREATEST(
+			ts_rank(to_tsvector('simple', f_unaccent(lower(farms.name || ' ' || farms.city))), q.ts),
+			word_similarity(q.term, f_unaccent(lower(farms.name))),
+			word_similarity(q.term, f_unaccent(lower(farms.city)))
+		) AS rank
+	FROM farms, q
+	WHERE farms.deleted_at IS NULL AND (
+		to_tsvector('simple', f_unaccent(lower(farms.name || ' ' || farms.city))) @@ q.ts
+		OR q.term <% f_unaccent(lower(farms.name))
+		OR q.term <% f_unaccent(lower(farms.city))
+	)
+) AS hits
+ORDER BY rank DESC, type DESC, id
+LIMIT ?`
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search returns up to limit farmers and farms matching term, best ranked first. term
+// is the folded query, tsquery its to_tsquery form and digits the numbers typed, used
+// to match documents.
+func (r *SearchRepository) Search(term, tsquery, digits string, limit int) ([]models.SearchHit, error) {
+	var hits []models.SearchHit
+	if err := r.db.Raw(searchQuery, term, tsquery, digits, limit).Scan(&hits).Error; err != nil {
+		return nil, translateError(err, searchMessages)
+	}
+	return hits, nil
+}
@@ -0,0 +1,105 @@
+// internal/repository/season_repository.go
+package repository
+
+import (
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SeasonRepository struct {
+	db *gorm.DB
+}
+
+func NewSeasonRepository(db *gorm.DB) *SeasonRepository {
+	return &SeasonRepository{db: db}
+}
+
+func (r *SeasonRepository) Create(season *models.Season) (*models.Season, error) {
+	if err := r.db.Create(season).Error; err != nil {
+		return nil, translateError(err, seasonMessages)
+	}
+	return season, nil
+}
+
+func (r *SeasonRepository) Update(season *models.Season) (*models.Season, error) {
+	result := r.db.Model(season).
+		Select("*").
+		Omit("ID", "CreatedAt", clause.Associations).
+		Updates(season)
+	if err := notFoundIfNoRows(result, seasonMessages); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(season.ID)
+}
+
+// Delete removes the season. It fails with a conflict while harvests, including the
+// ones in the trash, still reference it.
+func (r *SeasonRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Season{}, id)
+	return notFoundIfNoRows(result, seasonMessages)
+}
+
+func (r *SeasonRepository) GetByID(id uint) (*models.Season, error) {
+	var season models.Season
+	if err := r.db.First(&season, id).Error; err != nil {
+		return nil, translateError(err, seasonMessages)
+	}
+	return &season, nil
+}
+
+// GetAll lists the seasons, most recent first
+func (r *SeasonRepository) GetAll(params models.PaginationParams) ([]models.Season, int64, error) {
+	var seasons []models.Season
+	var total int64
+
+	// Count total records
+	if err := r.db.Model(&models.Season{}).Count(&total).Error; err != nil {
+		return nil, 0, translateError(err, seasonMessages)
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := r.db.Order("start_date DESC, id").Offset(offset).Limit(params.Limit).Find(&seasons).Error; err != nil {
+		return nil, 0, translateError(err, seasonMessages)
+	}
+
+	return seasons, total, nil
+}
+
+func (r *SeasonRepository) Exists(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Season{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, translateError(err, seasonMessages)
+	}
+	return count > 0, nil
+}
+
+// LockCycle takes a lock on the cycle that is held until the transaction ends, so that
+// the writes checking a cycle's seasons for overlaps run one at a time. The lock is
+// released at once outside a transaction.
+func (r *SeasonRepository) LockCycle(cycle string) error {
+	if err := r.db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "seasons:"+cycle).Error; err != nil {
+		return translateError(err, seasonMessages)
+	}
+	return nil
+}
+
+// FindOverlapping returns a season of the same cycle whose dates overlap the given
+// season, other than the season itself, or nil when there is none
+func (r *SeasonRepository) FindOverlapping(season *models.Season) (*models.Season, error) {
+	var seasons []models.Season
+	if err := r.db.
+		Where("cycle = ? AND id <> ?", season.Cycle, season.ID).
+		Where("start_date <= ? AND end_date >= ?", season.EndDate, season.StartDate).
+		Order("start_date").
+		Limit(1).
+		Find(&seasons).Error; err != nil {
+		return nil, translateError(err, seasonMessages)
+	}
+	if len(seasons) == 0 {
+		return nil, nil
+	}
+	return &seasons[0], nil
+}
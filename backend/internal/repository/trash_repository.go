@@ -0,0 +1,72 @@
+// internal/repository/trash_repository.go
+package repository
+
+import (
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var trashMessages = entityMessages{
+	notFound: "item não encontrado na lixeira",
+	conflict: "item da lixeira conflita com um registro existente",
+}
+
+// trashQuery lists every soft-deleted farmer, farm and harvest as a TrashItem
+const trashQuery = `
+SELECT 'farmer' AS type, id, name, NULL AS parent_id, deleted_at FROM farmers WHERE deleted_at IS NOT NULL
+UNION ALL
+SELECT 'farm' AS type, id, name, farmer_id AS parent_id, deleted_at FROM farms WHERE deleted_at IS NOT NULL
+UNION ALL
+SELECT 'harvest' AS type, id, culture AS name, farm_id AS parent_id, deleted_at FROM harvests WHERE deleted_at IS NOT NULL`
+
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// GetAll lists the soft-deleted records, most recently deleted first
+func (r *TrashRepository) GetAll(params models.PaginationParams) ([]models.TrashItem, int64, error) {
+	var items []models.TrashItem
+	var total int64
+
+	// Count total records
+	if err := r.db.Raw("SELECT COUNT(*) FROM (" + trashQuery + ") AS trash").Scan(&total).Error; err != nil {
+		return nil, 0, translateError(err, trashMessages)
+	}
+
+	// Apply pagination
+	offset := (params.Page - 1) * params.Limit
+	if err := r.db.Raw(
+		"SELECT * FROM ("+trashQuery+") AS trash ORDER BY deleted_at DESC, type, id LIMIT ? OFFSET ?",
+		params.Limit, offset,
+	).Scan(&items).Error; err != nil {
+		return nil, 0, translateError(err, trashMessages)
+	}
+
+	return items, total, nil
+}
+
+// Purge permanently removes the records deleted before the given instant and returns
+// how many rows were removed
+func (r *TrashRepository) Purge(before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.Harvest{}, &models.Farm{}, &models.Farmer{}} {
+			result := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, translateError(err, trashMessages)
+	}
+	return purged, nil
+}
@@ -0,0 +1,59 @@
+// internal/repository/trash_repository_test.go
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// trash moves a row of model to the trash at the given instant
+func trash(t *testing.T, db *gorm.DB, model interface{}, id uint, deletedAt time.Time) {
+	t.Helper()
+
+	if err := db.Model(model).Where("id = ?", id).UpdateColumn("deleted_at", deletedAt).Error; err != nil {
+		t.Fatalf("Failed to trash %T %d: %v", model, id, err)
+	}
+}
+
+func TestTrashRepository_Purge(t *testing.T) {
+	db := testDB(t)
+	repo := repository.NewTrashRepository(db)
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	old, recent := cutoff.Add(-time.Hour), cutoff.Add(time.Hour)
+
+	expired := createFarmer(t, db, "João da Silva", "52998224725", newFarm("Fazenda Boa Vista", newHarvest("Soja", 2024, 100)))
+	trash(t, db, &models.Harvest{}, expired.Farms[0].Harvests[0].ID, old)
+	trash(t, db, &models.Farm{}, expired.Farms[0].ID, old)
+	trash(t, db, &models.Farmer{}, expired.ID, old)
+
+	kept := createFarmer(t, db, "Maria Souza", "11144477735", newFarm("Fazenda Santa Rita", newHarvest("Soja", 2024, 100)))
+	trash(t, db, &models.Harvest{}, kept.Farms[0].Harvests[0].ID, recent)
+
+	purged, err := repo.Purge(cutoff)
+	if err != nil {
+		t.Fatalf("Purge returned error: %v", err)
+	}
+	if purged != 3 {
+		t.Errorf("Expected 3 rows purged, got %d", purged)
+	}
+
+	count := func(model interface{}, id uint) int64 {
+		var n int64
+		if err := db.Unscoped().Model(model).Where("id = ?", id).Count(&n).Error; err != nil {
+			t.Fatalf("Failed to count %T: %v", model, err)
+		}
+		return n
+	}
+	if count(&models.Farmer{}, expired.ID)+count(&models.Farm{}, expired.Farms[0].ID)+count(&models.Harvest{}, expired.Farms[0].Harvests[0].ID) != 0 {
+		t.Error("Expected the rows deleted before the cutoff to be gone")
+	}
+	// Rows deleted after the cutoff and rows never deleted are left alone
+	if count(&models.Harvest{}, kept.Farms[0].Harvests[0].ID) != 1 || count(&models.Farm{}, kept.Farms[0].ID) != 1 || count(&models.Farmer{}, kept.ID) != 1 {
+		t.Error("Expected the rows deleted after the cutoff and the active rows to be kept")
+	}
+}
@@ -0,0 +1,78 @@
+// internal/repository/unit_of_work.go
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+var transactionMessages = entityMessages{
+	notFound: "registro não encontrado",
+	conflict: "operação conflita com um registro existente",
+}
+
+// Repositories groups the repositories bound to the same database handle, so that
+// every repository used inside a unit of work shares its transaction
+type Repositories struct {
+	Farmers  *FarmerRepository
+	Farms    *FarmRepository
+	Harvests *HarvestRepository
+	Seasons  *SeasonRepository
+	Cultures *CultureRepository
+	Audit    *AuditRepository
+}
+
+// NewRepositories creates all repositories on top of the given handle
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Farmers:  NewFarmerRepository(db),
+		Farms:    NewFarmRepository(db),
+		Harvests: NewHarvestRepository(db),
+		Seasons:  NewSeasonRepository(db),
+		Cultures: NewCultureRepository(db),
+		Audit:    NewAuditRepository(db),
+	}
+}
+
+// UnitOfWork runs operations that span several repositories atomically
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do runs fn inside a database transaction. The transaction is committed when fn
+// returns nil and rolled back when fn returns an error or panics; a panic is
+// re-raised after the rollback.
+func (u *UnitOfWork) Do(fn func(repos *Repositories) error) error {
+	return u.DoContext(context.Background(), fn)
+}
+
+// DoContext is Do bound to ctx, usually the request's: once ctx is cancelled the
+// queries still to run fail and the transaction is rolled back instead of committed.
+func (u *UnitOfWork) DoContext(ctx context.Context, fn func(repos *Repositories) error) error {
+	tx := u.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return translateError(tx.Error, transactionMessages)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(NewRepositories(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return translateError(err, transactionMessages)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// internal/repository/unit_of_work_test.go
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// uowDocument is the CPF of the farmer the unit of work tests try to save
+const uowDocument = "39053344705"
+
+// uowFarmers counts the farmers saved by the unit of work tests, in the trash or not
+func uowFarmers(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+
+	var count int64
+	if err := db.Unscoped().Model(&models.Farmer{}).Where("federal_identification = ?", uowDocument).Count(&count).Error; err != nil {
+		t.Fatalf("Failed to count farmers: %v", err)
+	}
+	return count
+}
+
+// uowTest returns a unit of work on a connection of its own, as the units of work
+// commit or roll back real transactions
+func uowTest(t *testing.T) (*repository.UnitOfWork, *gorm.DB) {
+	t.Helper()
+
+	db := testConn(t)
+	clean := func() { db.Unscoped().Where("federal_identification = ?", uowDocument).Delete(&models.Farmer{}) }
+	clean()
+	t.Cleanup(clean)
+
+	return repository.NewUnitOfWork(db), db
+}
+
+func createUowFarmer(repos *repository.Repositories) error {
+	_, err := repos.Farmers.Create(&models.Farmer{FarmerName: "João da Silva", FederalIdentification: uowDocument})
+	return err
+}
+
+func TestUnitOfWork_Do(t *testing.T) {
+	t.Run("Commit", func(t *testing.T) {
+		uow, db := uowTest(t)
+
+		if err := uow.Do(createUowFarmer); err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		if count := uowFarmers(t, db); count != 1 {
+			t.Errorf("Expected the farmer to be committed, found %d", count)
+		}
+	})
+
+	t.Run("Rollback On Error", func(t *testing.T) {
+		uow, db := uowTest(t)
+		failure := errors.New("failure")
+
+		err := uow.Do(func(repos *repository.Repositories) error {
+			if err := createUowFarmer(repos); err != nil {
+				return err
+			}
+			return failure
+		})
+		if !errors.Is(err, failure) {
+			t.Fatalf("Expected the error of fn, got %v", err)
+		}
+		if count := uowFarmers(t, db); count != 0 {
+			t.Errorf("Expected the farmer to be rolled back, found %d", count)
+		}
+	})
+
+	t.Run("Rollback On Panic", func(t *testing.T) {
+		uow, db := uowTest(t)
+
+		func() {
+			defer func() {
+				if p := recover(); p != "failure" {
+					t.Errorf("Expected the panic to be re-raised, got %v", p)
+				}
+			}()
+			uow.Do(func(repos *repository.Repositories) error {
+				if err := createUowFarmer(repos); err != nil {
+					return err
+				}
+				panic("failure")
+			})
+		}()
+
+		if count := uowFarmers(t, db); count != 0 {
+			t.Errorf("Expected the farmer to be rolled back, found %d", count)
+		}
+	})
+}
+
+func TestUnitOfWork_DoContext(t *testing.T) {
+	t.Run("Cancelled Before", func(t *testing.T) {
+		uow, _ := uowTest(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		called := false
+		err := uow.DoContext(ctx, func(*repository.Repositories) error {
+			called = true
+			return nil
+		})
+		if err == nil || called {
+			t.Errorf("Expected the unit of work not to start, got error %v and called %v", err, called)
+		}
+	})
+
+	t.Run("Cancelled During", func(t *testing.T) {
+		uow, db := uowTest(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := uow.DoContext(ctx, func(repos *repository.Repositories) error {
+			if err := createUowFarmer(repos); err != nil {
+				return err
+			}
+			cancel()
+			return nil
+		})
+		if err == nil {
+			t.Fatal("Expected the commit to fail once the context is cancelled")
+		}
+		if count := uowFarmers(t, db); count != 0 {
+			t.Errorf("Expected the farmer to be rolled back, found %d", count)
+		}
+	})
+}
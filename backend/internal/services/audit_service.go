@@ -0,0 +1,225 @@
+// internal/services/audit_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+)
+
+// auditIgnoredFields are left out of the change list because they change on every write
+// or are derived from other fields
+var auditIgnoredFields = []string{"version", "created_at", "updated_at", "deleted_at", "yieldPerHectare", "areaDiscrepancy", "overlaps"}
+
+type AuditService struct {
+	repo *repository.AuditRepository
+}
+
+func NewAuditService(repo *repository.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// GetHistory lists the audit events of an entity and of its children, newest first
+func (s *AuditService) GetHistory(entityType string, entityID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	params = normalizePagination(params)
+
+	events, total, err := s.repo.GetByEntity(entityType, entityID, params)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	return models.NewPaginatedResult(events, total, params), nil
+}
+
+// recordAudit stores event with snapshots of the entity before and after the change,
+// the fields that changed and the actor and request ID carried by ctx. before is nil
+// for creations and after is nil for deletions.
+func recordAudit(ctx context.Context, repo *repository.AuditRepository, event models.AuditEvent, before, after interface{}) error {
+	beforeJSON, err := snapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshot(after)
+	if err != nil {
+		return err
+	}
+
+	changes, err := audit.Diff(beforeJSON, afterJSON, auditIgnoredFields...)
+	if err != nil {
+		return err
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	metadata := audit.FromContext(ctx)
+	event.Actor = metadata.Actor
+	event.RequestID = metadata.RequestID
+	event.Before = beforeJSON
+	event.After = afterJSON
+	event.Changes = changesJSON
+
+	return repo.Create(&event)
+}
+
+func snapshot(entity interface{}) (models.JSON, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	return models.JSON(data), nil
+}
+
+// farmerEvent, farmEvent and harvestEvent describe an audit event for the given entity,
+// linking farms to their farmer and harvests to their farm
+func farmerEvent(action string, farmer *models.Farmer) models.AuditEvent {
+	return models.AuditEvent{
+		EntityType: models.AuditEntityFarmer,
+		EntityID:   farmer.ID,
+		Action:     action,
+	}
+}
+
+func farmEvent(action string, farm *models.Farm) models.AuditEvent {
+	event := models.AuditEvent{
+		EntityType: models.AuditEntityFarm,
+		EntityID:   farm.ID,
+		Action:     action,
+	}
+	if farm.FarmerID != nil {
+		event.ParentType = models.AuditEntityFarmer
+		event.ParentID = farm.FarmerID
+	}
+	return event
+}
+
+func harvestEvent(action string, harvest *models.Harvest) models.AuditEvent {
+	event := models.AuditEvent{
+		EntityType: models.AuditEntityHarvest,
+		EntityID:   harvest.ID,
+		Action:     action,
+	}
+	if harvest.FarmID != nil {
+		event.ParentType = models.AuditEntityFarm
+		event.ParentID = harvest.FarmID
+	}
+	return event
+}
+
+// recordFarmsAudit records action for every farm of a farmer written or removed as a
+// whole, and for each of their harvests: the farms created with the farmer, deleted
+// with it or restored with it
+func recordFarmsAudit(ctx context.Context, repo *repository.AuditRepository, action string, farms []models.Farm) error {
+	for i := range farms {
+		farm := &farms[i]
+		if err := recordAudit(ctx, repo, farmEvent(action, farm), beforeState(action, farm), afterState(action, farm)); err != nil {
+			return err
+		}
+		for j := range farm.Harvests {
+			harvest := &farm.Harvests[j]
+			if err := recordAudit(ctx, repo, harvestEvent(action, harvest), beforeState(action, harvest), afterState(action, harvest)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordFarmChanges records the farm and harvest events behind the reconciliation of a
+// farmer's farms. before and after are the farms as stored around the write; the
+// harvests are only compared for the farms set in harvestsWritten, as the others kept
+// theirs untouched.
+func recordFarmChanges(ctx context.Context, repo *repository.AuditRepository, before, after []models.Farm, harvestsWritten map[uint]bool) error {
+	previous := make(map[uint]*models.Farm, len(before))
+	for i := range before {
+		previous[before[i].ID] = &before[i]
+	}
+
+	for i := range after {
+		farm := &after[i]
+		existing, ok := previous[farm.ID]
+		if !ok {
+			if err := recordFarmsAudit(ctx, repo, audit.ActionCreate, after[i:i+1]); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(previous, farm.ID)
+
+		if err := recordAudit(ctx, repo, farmEvent(audit.ActionUpdate, farm), existing, farm); err != nil {
+			return err
+		}
+		if !harvestsWritten[farm.ID] {
+			continue
+		}
+		if err := recordHarvestChanges(ctx, repo, existing.Harvests, farm.Harvests); err != nil {
+			return err
+		}
+	}
+
+	// The farms left are the ones the reconciliation deleted
+	for i := range before {
+		if _, ok := previous[before[i].ID]; ok {
+			if err := recordFarmsAudit(ctx, repo, audit.ActionDelete, before[i:i+1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordHarvestChanges records the events behind the reconciliation of a farm's harvests
+func recordHarvestChanges(ctx context.Context, repo *repository.AuditRepository, before, after []models.Harvest) error {
+	previous := make(map[uint]*models.Harvest, len(before))
+	for i := range before {
+		previous[before[i].ID] = &before[i]
+	}
+
+	for i := range after {
+		harvest := &after[i]
+		existing, ok := previous[harvest.ID]
+		var err error
+		if ok {
+			delete(previous, harvest.ID)
+			err = recordAudit(ctx, repo, harvestEvent(audit.ActionUpdate, harvest), existing, harvest)
+		} else {
+			err = recordAudit(ctx, repo, harvestEvent(audit.ActionCreate, harvest), nil, harvest)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range before {
+		harvest := &before[i]
+		if _, ok := previous[harvest.ID]; ok {
+			if err := recordAudit(ctx, repo, harvestEvent(audit.ActionDelete, harvest), harvest, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// beforeState and afterState return the snapshots an event of the given action is
+// recorded with: deletions have no after state, creations and restores no before one
+func beforeState(action string, entity interface{}) interface{} {
+	if action == audit.ActionDelete {
+		return entity
+	}
+	return nil
+}
+
+func afterState(action string, entity interface{}) interface{} {
+	if action == audit.ActionDelete {
+		return nil
+	}
+	return entity
+}
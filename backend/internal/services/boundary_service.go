@@ -0,0 +1,204 @@
+// internal/services/boundary_service.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+	"github.com/samuel-prates/farm-project/backend/pkg/kml"
+	"github.com/samuel-prates/farm-project/backend/pkg/shapefile"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+// errUnsupportedBoundaryFile is reported for zip archives holding neither a KML
+// document nor a shapefile
+var errUnsupportedBoundaryFile = errors.New("o arquivo deve ser KML, KMZ ou um shapefile compactado em zip")
+
+// shapefileFields are the attributes exported with each farm in a shapefile
+var shapefileFields = []shapefile.Field{
+	{Name: "ID", Type: shapefile.Numeric, Length: 10},
+	{Name: "NAME", Type: shapefile.Character, Length: 100},
+	{Name: "OWNER", Type: shapefile.Character, Length: 100},
+	{Name: "CITY", Type: shapefile.Character, Length: 60},
+	{Name: "STATE", Type: shapefile.Character, Length: 30},
+	{Name: "TOTAL_HA", Type: shapefile.Numeric, Length: 18, Decimals: 4},
+	{Name: "ARABLE_HA", Type: shapefile.Numeric, Length: 18, Decimals: 4},
+	{Name: "VEG_HA", Type: shapefile.Numeric, Length: 18, Decimals: 4},
+	{Name: "BOUND_HA", Type: shapefile.Numeric, Length: 18, Decimals: 4},
+	{Name: "CULTURE", Type: shapefile.Character, Length: 60},
+}
+
+// BoundaryService imports farm boundaries from the KML, KMZ and shapefile files
+// handed out by CAR and SIGEF, and exports them back to those formats
+type BoundaryService struct {
+	farmRepo    *repository.FarmRepository
+	farmService *FarmService
+	mapService  *MapService
+}
+
+func NewBoundaryService(farmRepo *repository.FarmRepository, farmService *FarmService, mapService *MapService) *BoundaryService {
+	return &BoundaryService{
+		farmRepo:    farmRepo,
+		farmService: farmService,
+		mapService:  mapService,
+	}
+}
+
+// Import replaces the boundary of the farm with every polygon of the file. The format
+// is detected from the content.
+func (s *BoundaryService) Import(ctx context.Context, farmID uint, data []byte, ifMatch string) (*models.Farm, error) {
+	boundary, err := readBoundary(data)
+	if err != nil {
+		var errs validation.Errors
+		errs.Add("file", validation.CodeInvalid, err.Error())
+		return nil, errs.Err()
+	}
+
+	return s.farmService.SetBoundary(ctx, farmID, boundary, ifMatch)
+}
+
+// ExportFarm encodes the boundary of a single farm
+func (s *BoundaryService) ExportFarm(id uint, format string) (*models.BoundaryFile, error) {
+	if err := validateBoundaryFormat(format); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.farmRepo.GetMapEntry(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Boundary == nil {
+		return nil, ErrBoundaryNotFound
+	}
+
+	return encodeBoundaries(fmt.Sprintf("fazenda-%d", id), format, []models.FarmMapEntry{*entry})
+}
+
+// Export encodes the boundaries of the farms shown on the map for the filter
+func (s *BoundaryService) Export(filter models.MapFilter, format string) (*models.BoundaryFile, error) {
+	if err := validateBoundaryFormat(format); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.mapService.GetEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// the farms the map draws from their centroid have no boundary to export
+	outlined := entries[:0]
+	for _, entry := range entries {
+		if entry.Boundary != nil {
+			outlined = append(outlined, entry)
+		}
+	}
+
+	return encodeBoundaries("fazendas", format, outlined)
+}
+
+// readBoundary tells the formats apart by their content: zip archives are KMZ when
+// they hold a KML document and zipped shapefiles otherwise
+func readBoundary(data []byte) (*geo.Geometry, error) {
+	switch {
+	case kml.IsKMZ(data):
+		boundary, err := kml.Read(data)
+		if !errors.Is(err, kml.ErrNoDocument) {
+			return boundary, err
+		}
+		boundary, err = shapefile.Read(data)
+		if errors.Is(err, shapefile.ErrNoShapefile) {
+			return nil, errUnsupportedBoundaryFile
+		}
+		return boundary, err
+	case shapefile.IsShp(data):
+		return shapefile.Read(data)
+	default:
+		return kml.Read(data)
+	}
+}
+
+func validateBoundaryFormat(format string) error {
+	switch format {
+	case models.BoundaryFormatKML, models.BoundaryFormatKMZ, models.BoundaryFormatShapefile:
+		return nil
+	}
+
+	var errs validation.Errors
+	errs.Add("format", validation.CodeInvalid, "format deve ser kml, kmz ou shp")
+	return errs.Err()
+}
+
+func encodeBoundaries(name, format string, entries []models.FarmMapEntry) (*models.BoundaryFile, error) {
+	var buf bytes.Buffer
+	file := &models.BoundaryFile{}
+
+	switch format {
+	case models.BoundaryFormatKML, models.BoundaryFormatKMZ:
+		placemarks := make([]kml.Placemark, len(entries))
+		for i, entry := range entries {
+			placemarks[i] = kml.Placemark{Name: entry.Name, Data: placemarkData(entry), Geometry: entry.Boundary}
+		}
+
+		if format == models.BoundaryFormatKMZ {
+			file.Name, file.ContentType = name+".kmz", kml.KMZContentType
+			if err := kml.WriteKMZ(&buf, name, placemarks); err != nil {
+				return nil, err
+			}
+		} else {
+			file.Name, file.ContentType = name+".kml", kml.ContentType
+			if err := kml.Write(&buf, name, placemarks); err != nil {
+				return nil, err
+			}
+		}
+	case models.BoundaryFormatShapefile:
+		records := make([]shapefile.Record, len(entries))
+		for i, entry := range entries {
+			records[i] = shapefile.Record{Geometry: entry.Boundary, Values: []interface{}{
+				entry.ID, entry.Name, entry.Owner, entry.City, entry.State,
+				entry.TotalArea, entry.AgricultureArea, entry.VegetationArea, entry.BoundaryArea,
+				entry.LatestCulture,
+			}}
+		}
+
+		file.Name, file.ContentType = name+".zip", shapefile.ContentType
+		if err := shapefile.Write(&buf, name, shapefileFields, records); err != nil {
+			return nil, err
+		}
+	}
+
+	file.Data = buf.Bytes()
+	return file, nil
+}
+
+// placemarkData lists the farm properties written to the KML ExtendedData, under the
+// same names the map uses
+func placemarkData(entry models.FarmMapEntry) []kml.Data {
+	data := []kml.Data{
+		{Name: "id", Value: strconv.FormatUint(uint64(entry.ID), 10)},
+		{Name: "city", Value: entry.City},
+		{Name: "state", Value: entry.State},
+		{Name: "totalArea", Value: formatArea(entry.TotalArea)},
+		{Name: "arableArea", Value: formatArea(entry.AgricultureArea)},
+		{Name: "vegetationArea", Value: formatArea(entry.VegetationArea)},
+	}
+	if entry.Owner != "" {
+		data = append(data, kml.Data{Name: "owner", Value: entry.Owner})
+	}
+	if entry.BoundaryArea != nil {
+		data = append(data, kml.Data{Name: "boundaryArea", Value: formatArea(*entry.BoundaryArea)})
+	}
+	if entry.LatestCulture != "" {
+		data = append(data, kml.Data{Name: "latestCulture", Value: entry.LatestCulture})
+	}
+	return data
+}
+
+func formatArea(area float64) string {
+	return strconv.FormatFloat(area, 'f', -1, 64)
+}
@@ -0,0 +1,184 @@
+// internal/services/culture_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+type CultureService struct {
+	repo *repository.CultureRepository
+	uow  *repository.UnitOfWork
+}
+
+func NewCultureService(repo *repository.CultureRepository, uow *repository.UnitOfWork) *CultureService {
+	return &CultureService{
+		repo: repo,
+		uow:  uow,
+	}
+}
+
+func (s *CultureService) Create(culture *models.Culture) (*models.Culture, error) {
+	if err := culture.Validate(); err != nil {
+		return nil, err
+	}
+	culture.Normalize()
+
+	var created *models.Culture
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		if err := ensureCultureNamesAvailable(repos.Cultures, culture); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = repos.Cultures.Create(culture)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Update saves the culture and renames the harvests that reference it
+func (s *CultureService) Update(culture *models.Culture) (*models.Culture, error) {
+	if err := culture.Validate(); err != nil {
+		return nil, err
+	}
+	culture.Normalize()
+
+	var updated *models.Culture
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		if _, err := repos.Cultures.GetByID(culture.ID); err != nil {
+			return err
+		}
+		if err := ensureCultureNamesAvailable(repos.Cultures, culture); err != nil {
+			return err
+		}
+
+		var err error
+		updated, err = repos.Cultures.Update(culture)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *CultureService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *CultureService) GetByID(id uint) (*models.Culture, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *CultureService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	params = normalizePagination(params)
+
+	cultures, total, err := s.repo.GetAll(params)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	return models.NewPaginatedResult(cultures, total, params), nil
+}
+
+// ensureCultureNamesAvailable rejects a culture whose name or aliases already name
+// another culture of the catalog. Names are unique among cultures and among aliases at
+// the database level, but a name may still clash with an alias.
+func ensureCultureNamesAvailable(repo *repository.CultureRepository, culture *models.Culture) error {
+	for _, name := range culture.Names() {
+		other, err := repo.FindByNormalizedName(models.NormalizeCultureName(name))
+		if err != nil {
+			return err
+		}
+		if other != nil && other.ID != culture.ID {
+			return apperrors.Conflict(fmt.Sprintf("o nome %q já pertence à cultura %q", name, other.Name))
+		}
+	}
+	return nil
+}
+
+// resolveCulture points harvest at its catalog culture, looked up by culture_id or
+// else by the name or an alias, and stores the canonical name and, when the harvest
+// has no unit, the culture's default unit. Cultures missing from the catalog are
+// added to errs under prefix.
+func resolveCulture(repo *repository.CultureRepository, harvest *models.Harvest, prefix string, errs *validation.Errors) error {
+	if harvest.CultureID != nil {
+		culture, err := repo.GetByID(*harvest.CultureID)
+		if errors.Is(err, apperrors.ErrNotFound) {
+			errs.Add(validation.Join(prefix, "culture_id"), validation.CodeInvalid, "cultura não encontrada no catálogo")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		useCulture(harvest, *culture, prefix, errs)
+		return nil
+	}
+
+	culture, err := repo.FindByNormalizedName(models.NormalizeCultureName(harvest.Culture))
+	if err != nil {
+		return err
+	}
+	if culture == nil {
+		errs.Add(validation.Join(prefix, "culture"), validation.CodeInvalid, fmt.Sprintf("cultura %q não cadastrada no catálogo", harvest.Culture))
+		return nil
+	}
+	useCulture(harvest, *culture, prefix, errs)
+	return nil
+}
+
+// useCulture links the harvest to the catalog culture and requires a unit for its
+// quantity when the culture has no default one
+func useCulture(harvest *models.Harvest, culture models.Culture, prefix string, errs *validation.Errors) {
+	harvest.UseCulture(culture)
+	if harvest.MissingUnit() {
+		errs.Add(validation.Join(prefix, "unit"), validation.CodeRequired, "unidade é obrigatória quando há quantidade produzida")
+	}
+}
+
+// resolveHarvestCultures runs resolveCulture over a farm's harvests
+func resolveHarvestCultures(repo *repository.CultureRepository, harvests []models.Harvest, prefix string, errs *validation.Errors) error {
+	for i := range harvests {
+		if err := resolveCulture(repo, &harvests[i], validation.Index(validation.Join(prefix, "harvests"), i), errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveCultureFilter looks the culture a filter names up in the catalog, by its name
+// or one of its aliases. A culture missing from the catalog resolves to ID 0, which no
+// harvest references, so the filter matches nothing instead of being dropped.
+func resolveCultureFilter(repo *repository.CultureRepository, name string) (*uint, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	culture, err := repo.FindByNormalizedName(models.NormalizeCultureName(name))
+	if err != nil {
+		return nil, err
+	}
+	var id uint
+	if culture != nil {
+		id = culture.ID
+	}
+	return &id, nil
+}
+
+// resolveDashboardFilter fills in the catalog culture of a dashboard filter
+func resolveDashboardFilter(repo *repository.CultureRepository, filter models.DashboardFilter) (models.DashboardFilter, error) {
+	var err error
+	filter.CultureID, err = resolveCultureFilter(repo, filter.Culture)
+	return filter, err
+}
@@ -2,8 +2,11 @@
 package services
 
 import (
+	"context"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
 	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"golang.org/x/sync/errgroup"
 )
 
 type DashboardData struct {
@@ -16,56 +19,167 @@ type AreaDistribution struct {
 	VegetationArea  float64 `json:"vegetationArea"`
 }
 
+// DashboardSummary gathers every dashboard widget in a single payload
+type DashboardSummary struct {
+	TotalFarms       int                          `json:"totalFarms"`
+	TotalArea        float64                      `json:"totalArea"`
+	FarmsByState     []models.StateCount          `json:"farmsByState"`
+	HarvestCultures  []models.HarvestCultureCount `json:"harvestCultures"`
+	AreaDistribution AreaDistribution             `json:"areaDistribution"`
+}
+
 type DashboardService struct {
 	farmRepo    *repository.FarmRepository
 	harvestRepo *repository.HarvestRepository
+	cultureRepo *repository.CultureRepository
 }
 
-func NewDashboardService(farmRepo *repository.FarmRepository, harvestRepo *repository.HarvestRepository) *DashboardService {
+func NewDashboardService(farmRepo *repository.FarmRepository, harvestRepo *repository.HarvestRepository, cultureRepo *repository.CultureRepository) *DashboardService {
 	return &DashboardService{
 		farmRepo:    farmRepo,
 		harvestRepo: harvestRepo,
+		cultureRepo: cultureRepo,
 	}
 }
 
-func (s *DashboardService) GetDashboardData() (*DashboardData, error) {
-	totalFarms, err := s.farmRepo.Count()
+func (s *DashboardService) GetDashboardData(filter models.DashboardFilter) (*DashboardData, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	totalArea, err := s.farmRepo.SumTotalArea()
+	totals, err := s.farmRepo.Totals(filter)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DashboardData{
-		TotalFarms: totalFarms,
-		TotalArea:  totalArea,
+		TotalFarms: totals.Count,
+		TotalArea:  totals.TotalArea,
 	}, nil
 }
 
-func (s *DashboardService) GetFarmsByState() ([]models.StateCount, error) {
-	return s.farmRepo.CountByState()
+func (s *DashboardService) GetFarmsByState(filter models.DashboardFilter) ([]models.StateCount, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.farmRepo.CountByState(filter)
 }
 
-func (s *DashboardService) GetHarvestTypes() ([]models.HarvestCultureCount, error) {
-	return s.harvestRepo.CountByType()
+func (s *DashboardService) GetHarvestTypes(filter models.DashboardFilter, byYear bool) ([]models.HarvestCultureCount, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.harvestRepo.CountByCulture(filter, byYear)
 }
 
-func (s *DashboardService) GetAreaDistribution() (*AreaDistribution, error) {
-	agricultureArea, err := s.farmRepo.SumAgricultureArea()
+func (s *DashboardService) GetAreaDistribution(filter models.DashboardFilter) (*AreaDistribution, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	vegetationArea, err := s.farmRepo.SumVegetationArea()
+	totals, err := s.farmRepo.Totals(filter)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AreaDistribution{
-		AgricultureArea: agricultureArea,
-		VegetationArea:  vegetationArea,
+		AgricultureArea: totals.AgricultureArea,
+		VegetationArea:  totals.VegetationArea,
+	}, nil
+}
+
+// GetProductionByCulture reports how much of each culture was planted and produced,
+// with the resulting yield per hectare
+func (s *DashboardService) GetProductionByCulture(filter models.DashboardFilter) ([]models.CultureProduction, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	production, err := s.harvestRepo.ProductionByCulture(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range production {
+		setYield(&production[i])
+	}
+	return production, nil
+}
+
+// GetProductionBySeason reports the production of each culture per season
+func (s *DashboardService) GetProductionBySeason(filter models.DashboardFilter) ([]models.SeasonProduction, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	production, err := s.harvestRepo.ProductionBySeason(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range production {
+		setYield(&production[i].CultureProduction)
+	}
+	return production, nil
+}
+
+func setYield(production *models.CultureProduction) {
+	if production.PlantedArea > 0 {
+		yield := production.Quantity / production.PlantedArea
+		production.YieldPerHectare = &yield
+	}
+}
+
+// GetSummary runs the totals, state and culture queries concurrently, resolving the
+// culture filter once beforehand. The first failure cancels the queries still running.
+func (s *DashboardService) GetSummary(ctx context.Context, filter models.DashboardFilter) (*DashboardSummary, error) {
+	filter, err := resolveDashboardFilter(s.cultureRepo.WithContext(ctx), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	farmRepo := s.farmRepo.WithContext(ctx)
+	harvestRepo := s.harvestRepo.WithContext(ctx)
+
+	var totals models.FarmTotals
+	var states []models.StateCount
+	var cultures []models.HarvestCultureCount
+
+	group.Go(func() error {
+		var err error
+		totals, err = farmRepo.Totals(filter)
+		return err
+	})
+	group.Go(func() error {
+		var err error
+		states, err = farmRepo.CountByState(filter)
+		return err
+	})
+	group.Go(func() error {
+		var err error
+		cultures, err = harvestRepo.CountByCulture(filter, false)
+		return err
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &DashboardSummary{
+		TotalFarms:      totals.Count,
+		TotalArea:       totals.TotalArea,
+		FarmsByState:    states,
+		HarvestCultures: cultures,
+		AreaDistribution: AreaDistribution{
+			AgricultureArea: totals.AgricultureArea,
+			VegetationArea:  totals.VegetationArea,
+		},
 	}, nil
 }
@@ -0,0 +1,16 @@
+// internal/services/errors.go
+package services
+
+import "github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+
+// ErrFarmerNotFound is returned when an operation references a farmer that does not exist
+var ErrFarmerNotFound = apperrors.NotFound("fazendeiro não encontrado")
+
+// ErrFarmNotFound is returned when an operation references a farm that does not exist
+var ErrFarmNotFound = apperrors.NotFound("fazenda não encontrada")
+
+// ErrSeasonNotFound is returned when an operation references a season that does not exist
+var ErrSeasonNotFound = apperrors.NotFound("temporada não encontrada")
+
+// ErrBoundaryNotFound is returned when exporting a farm that has no boundary
+var ErrBoundaryNotFound = apperrors.NotFound("a fazenda não possui limite cadastrado")
@@ -2,28 +2,213 @@
 package services
 
 import (
+	"context"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
 	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
 )
 
 type FarmService struct {
-	repo *repository.FarmRepository
+	repo        *repository.FarmRepository
+	farmerRepo  *repository.FarmerRepository
+	cultureRepo *repository.CultureRepository
+	uow         *repository.UnitOfWork
+}
+
+func NewFarmService(repo *repository.FarmRepository, farmerRepo *repository.FarmerRepository, cultureRepo *repository.CultureRepository, uow *repository.UnitOfWork) *FarmService {
+	return &FarmService{
+		repo:        repo,
+		farmerRepo:  farmerRepo,
+		cultureRepo: cultureRepo,
+		uow:         uow,
+	}
 }
 
-func NewFarmService(repo *repository.FarmRepository) *FarmService {
-	return &FarmService{repo: repo}
+// Create saves the farm and reports the farms its boundary overlaps. Overlaps are not
+// rejected: they are left for someone to look into.
+func (s *FarmService) Create(ctx context.Context, farm *models.Farm) (*models.Farm, error) {
+	if err := farm.Validate(); err != nil {
+		return nil, err
+	}
+	farm.MeasureBoundary()
+
+	var created *models.Farm
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		if farm.FarmerID != nil {
+			if err := ensureFarmerExists(repos.Farmers, *farm.FarmerID); err != nil {
+				return err
+			}
+		}
+		if err := ensureFarmCultures(repos.Cultures, farm); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = repos.Farms.Create(farm)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, repos.Audit, farmEvent(audit.ActionCreate, created), nil, created); err != nil {
+			return err
+		}
+
+		created.Overlaps, err = findOverlaps(repos.Farms, created)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *FarmService) Update(ctx context.Context, farm *models.Farm, ifMatch string) (*models.Farm, error) {
+	if err := farm.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Farm
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farms.GetByID(farm.ID)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, farm, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Patch applies an RFC 7396 merge patch to the stored farm, validates the merged result
+// and saves it. Harvests are only reconciled when the patch names them.
+func (s *FarmService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farm, error) {
+	var updated *models.Farm
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farms.GetByID(id)
+		if err != nil {
+			return err
+		}
+
+		var farm models.Farm
+		fields, err := applyMergePatch(existing, patch, &farm)
+		if err != nil {
+			return err
+		}
+		farm.ID = id
+		if !fields["harvests"] {
+			farm.Harvests = nil
+		}
+
+		if err := farm.Validate(); err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, &farm, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-func (s *FarmService) Create(farm *models.Farm) (*models.Farm, error) {
-	return s.repo.Create(farm)
+// update saves farm over existing, provided ifMatch still matches it, and reports the
+// farms its boundary overlaps
+func (s *FarmService) update(ctx context.Context, repos *repository.Repositories, existing, farm *models.Farm, ifMatch string) (*models.Farm, error) {
+	if err := checkIfMatch(ifMatch, existing.ETag()); err != nil {
+		return nil, err
+	}
+	farm.Version = existing.Version
+	farm.MeasureBoundary()
+
+	// Keep the current owner when the request does not move the farm
+	if farm.FarmerID == nil {
+		farm.FarmerID = existing.FarmerID
+	} else if err := ensureFarmerExists(repos.Farmers, *farm.FarmerID); err != nil {
+		return nil, err
+	}
+
+	if err := ensureFarmCultures(repos.Cultures, farm); err != nil {
+		return nil, err
+	}
+
+	// Harvests kept as they are must still fit in the new arable area
+	if farm.Harvests == nil {
+		var errs validation.Errors
+		ensureHarvestsFit(existing.Harvests, farm.AgricultureArea, "arableArea", &errs)
+		if err := errs.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := repos.Farms.Update(farm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, repos.Audit, farmEvent(audit.ActionUpdate, updated), existing, updated); err != nil {
+		return nil, err
+	}
+
+	updated.Overlaps, err = findOverlaps(repos.Farms, updated)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
-func (s *FarmService) Update(farm *models.Farm) (*models.Farm, error) {
-	return s.repo.Update(farm)
+// SetBoundary replaces the boundary of the farm, keeping its other fields and harvests
+func (s *FarmService) SetBoundary(ctx context.Context, id uint, boundary *geo.Geometry, ifMatch string) (*models.Farm, error) {
+	var updated *models.Farm
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farms.GetByID(id)
+		if err != nil {
+			return err
+		}
+
+		farm := *existing
+		farm.Boundary = boundary
+		farm.Harvests = nil
+		if err := farm.Validate(); err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, &farm, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-func (s *FarmService) Delete(id uint) error {
-	return s.repo.Delete(id)
+func (s *FarmService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farms.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(ifMatch, existing.ETag()); err != nil {
+			return err
+		}
+
+		if err := repos.Farms.Delete(id); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos.Audit, farmEvent(audit.ActionDelete, existing), existing, nil)
+	})
 }
 
 func (s *FarmService) GetByID(id uint) (*models.Farm, error) {
@@ -31,18 +216,99 @@ func (s *FarmService) GetByID(id uint) (*models.Farm, error) {
 }
 
 func (s *FarmService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
-	// Set default values if not provided
-	if params.Page <= 0 {
-		params.Page = 1
+	params = normalizePagination(params)
+
+	farms, total, err := s.repo.GetAll(params)
+	if err != nil {
+		return models.PaginatedResult{}, err
 	}
-	if params.Limit <= 0 {
-		params.Limit = 10
+
+	return models.NewPaginatedResult(farms, total, params), nil
+}
+
+// GetPage returns a keyset page, counting the whole list only when asked to
+func (s *FarmService) GetPage(params models.CursorParams) (models.CursorResult, error) {
+	params = normalizeCursor(params)
+
+	farms, next, prev, err := s.repo.GetPage(params.Cursor, params.Limit)
+	if err != nil {
+		return models.CursorResult{}, err
 	}
 
-	farms, total, err := s.repo.GetAll(params)
+	var total *int64
+	if params.WithTotal {
+		count, err := s.repo.Count(models.DashboardFilter{})
+		if err != nil {
+			return models.CursorResult{}, err
+		}
+		count64 := int64(count)
+		total = &count64
+	}
+
+	return newCursorResult(farms, next, prev, total, params), nil
+}
+
+func (s *FarmService) GetAllByFarmer(farmerID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	if err := ensureFarmerExists(s.farmerRepo, farmerID); err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	params = normalizePagination(params)
+
+	farms, total, err := s.repo.GetAllByFarmer(farmerID, params)
 	if err != nil {
 		return models.PaginatedResult{}, err
 	}
 
 	return models.NewPaginatedResult(farms, total, params), nil
 }
+
+// GetNearby lists the farms whose centroid is within the radius of the searched
+// position, nearest first. Farms at the same distance are listed in ID order.
+func (s *FarmService) GetNearby(filter models.NearbyFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	params = normalizePagination(params)
+
+	dashboard, err := resolveDashboardFilter(s.cultureRepo, filter.DashboardFilter)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	farms, total, err := s.repo.GetNearby(geo.Position{filter.Lon, filter.Lat}, filter.RadiusKm*1000, dashboard, params)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	return models.NewPaginatedResult(farms, total, params), nil
+}
+
+// ensureFarmCultures resolves the cultures of the farm's harvests against the catalog
+func ensureFarmCultures(repo *repository.CultureRepository, farm *models.Farm) error {
+	var errs validation.Errors
+	if err := resolveHarvestCultures(repo, farm.Harvests, "", &errs); err != nil {
+		return err
+	}
+	return errs.Err()
+}
+
+// ensureHarvestsFit records an error on field when the harvests a write keeps as they
+// are planted more, in some period, than the farm's new arable area
+func ensureHarvestsFit(harvests []models.Harvest, arableArea float64, field string, errs *validation.Errors) {
+	for _, planted := range models.PlantedAreaByPeriod(harvests) {
+		if planted > arableArea {
+			errs.Add(field, validation.CodeExceeded, "a área agrícola não pode ser menor que a área plantada em um mesmo período")
+			return
+		}
+	}
+}
+
+// ensureFarmExists returns ErrFarmNotFound when the farm does not exist
+func ensureFarmExists(repo *repository.FarmRepository, farmID uint) error {
+	exists, err := repo.Exists(farmID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrFarmNotFound
+	}
+	return nil
+}
@@ -2,47 +2,297 @@
 package services
 
 import (
+	"context"
+
 	"github.com/samuel-prates/farm-project/backend/internal/models"
 	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
 )
 
 type FarmerService struct {
-	repo *repository.FarmerRepository
+	repo        *repository.FarmerRepository
+	cultureRepo *repository.CultureRepository
+	uow         *repository.UnitOfWork
 }
 
-func NewFarmerService(repo *repository.FarmerRepository) *FarmerService {
-	return &FarmerService{repo: repo}
+func NewFarmerService(repo *repository.FarmerRepository, cultureRepo *repository.CultureRepository, uow *repository.UnitOfWork) *FarmerService {
+	return &FarmerService{
+		repo:        repo,
+		cultureRepo: cultureRepo,
+		uow:         uow,
+	}
 }
 
-func (s *FarmerService) Create(farmer *models.Farmer) (*models.Farmer, error) {
-	return s.repo.Create(farmer)
+// Create saves the farmer with its farms and reports the farms each boundary overlaps,
+// as FarmService.Create does for a single farm
+func (s *FarmerService) Create(ctx context.Context, farmer *models.Farmer) (*models.Farmer, error) {
+	if err := farmer.Validate(); err != nil {
+		return nil, err
+	}
+	if err := farmer.NormalizeDocument(); err != nil {
+		return nil, err
+	}
+	measureFarmBoundaries(farmer)
+
+	var created *models.Farmer
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		if err := ensureFarmerCultures(repos.Cultures, farmer); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = repos.Farmers.Create(farmer)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, repos.Audit, farmerEvent(audit.ActionCreate, created), nil, created); err != nil {
+			return err
+		}
+		if err := recordFarmsAudit(ctx, repos.Audit, audit.ActionCreate, created.Farms); err != nil {
+			return err
+		}
+		return findFarmerOverlaps(repos.Farms, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *FarmerService) Update(ctx context.Context, farmer *models.Farmer, ifMatch string) (*models.Farmer, error) {
+	if err := farmer.Validate(); err != nil {
+		return nil, err
+	}
+	if err := farmer.NormalizeDocument(); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Farmer
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farmers.GetByID(farmer.ID)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, farmer, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-func (s *FarmerService) Update(farmer *models.Farmer) (*models.Farmer, error) {
-	return s.repo.Update(farmer)
+// Patch applies an RFC 7396 merge patch to the stored farmer, validates the merged
+// result and saves it. Farms are only reconciled when the patch names them.
+func (s *FarmerService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Farmer, error) {
+	var updated *models.Farmer
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farmers.GetByID(id)
+		if err != nil {
+			return err
+		}
+
+		var farmer models.Farmer
+		fields, err := applyMergePatch(existing, patch, &farmer)
+		if err != nil {
+			return err
+		}
+		farmer.ID = id
+		if !fields["farms"] {
+			farmer.Farms = nil
+		}
+
+		if err := farmer.Validate(); err != nil {
+			return err
+		}
+		if err := farmer.NormalizeDocument(); err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, &farmer, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// update saves farmer over existing, provided ifMatch still matches it, and records an
+// event for the farmer and for each farm and harvest the write touched. When the farms
+// were written, the overlaps of their boundaries are reported too.
+func (s *FarmerService) update(ctx context.Context, repos *repository.Repositories, existing, farmer *models.Farmer, ifMatch string) (*models.Farmer, error) {
+	if err := checkIfMatch(ifMatch, existing.ETag()); err != nil {
+		return nil, err
+	}
+	farmer.Version = existing.Version
+	measureFarmBoundaries(farmer)
+
+	if err := ensureKeptHarvestsFit(existing, farmer); err != nil {
+		return nil, err
+	}
+	if err := ensureFarmerCultures(repos.Cultures, farmer); err != nil {
+		return nil, err
+	}
+
+	updated, err := repos.Farmers.Update(farmer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, repos.Audit, farmerEvent(audit.ActionUpdate, updated), existing, updated); err != nil {
+		return nil, err
+	}
+
+	// A nil Farms slice left the farms alone; otherwise each one was written
+	if farmer.Farms != nil {
+		harvestsWritten := make(map[uint]bool, len(farmer.Farms))
+		for _, farm := range farmer.Farms {
+			harvestsWritten[farm.ID] = farm.Harvests != nil
+		}
+		if err := recordFarmChanges(ctx, repos.Audit, existing.Farms, updated.Farms, harvestsWritten); err != nil {
+			return nil, err
+		}
+		if err := findFarmerOverlaps(repos.Farms, updated); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
 }
 
-func (s *FarmerService) Delete(id uint) error {
-	return s.repo.Delete(id)
+func (s *FarmerService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Farmers.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(ifMatch, existing.ETag()); err != nil {
+			return err
+		}
+
+		if err := repos.Farmers.Delete(id); err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, repos.Audit, farmerEvent(audit.ActionDelete, existing), existing, nil); err != nil {
+			return err
+		}
+		return recordFarmsAudit(ctx, repos.Audit, audit.ActionDelete, existing.Farms)
+	})
 }
 
 func (s *FarmerService) GetByID(id uint) (*models.Farmer, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *FarmerService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
-	// Set default values if not provided
-	if params.Page <= 0 {
-		params.Page = 1
-	}
-	if params.Limit <= 0 {
-		params.Limit = 10
+func (s *FarmerService) GetAll(filter models.FarmerFilter, params models.PaginationParams) (models.PaginatedResult, error) {
+	params = normalizePagination(params)
+
+	var err error
+	filter.CultureID, err = resolveCultureFilter(s.cultureRepo, filter.Culture)
+	if err != nil {
+		return models.PaginatedResult{}, err
 	}
 
-	farmers, total, err := s.repo.GetAll(params)
+	farmers, total, err := s.repo.GetAll(filter, params)
 	if err != nil {
 		return models.PaginatedResult{}, err
 	}
 
 	return models.NewPaginatedResult(farmers, total, params), nil
 }
+
+// GetPage returns a keyset page, counting the whole list only when asked to
+func (s *FarmerService) GetPage(filter models.FarmerFilter, params models.CursorParams) (models.CursorResult, error) {
+	params = normalizeCursor(params)
+
+	var err error
+	filter.CultureID, err = resolveCultureFilter(s.cultureRepo, filter.Culture)
+	if err != nil {
+		return models.CursorResult{}, err
+	}
+
+	farmers, next, prev, err := s.repo.GetPage(filter, params.Cursor, params.Limit)
+	if err != nil {
+		return models.CursorResult{}, err
+	}
+
+	var total *int64
+	if params.WithTotal {
+		count, err := s.repo.Count(filter)
+		if err != nil {
+			return models.CursorResult{}, err
+		}
+		total = &count
+	}
+
+	return newCursorResult(farmers, next, prev, total, params), nil
+}
+
+// measureFarmBoundaries measures the boundaries of the farmer's farms
+func measureFarmBoundaries(farmer *models.Farmer) {
+	for i := range farmer.Farms {
+		farmer.Farms[i].MeasureBoundary()
+	}
+}
+
+// findFarmerOverlaps reports the farms the boundary of each of the farmer's farms
+// overlaps, the farmer's own farms included
+func findFarmerOverlaps(repo *repository.FarmRepository, farmer *models.Farmer) error {
+	for i := range farmer.Farms {
+		overlaps, err := findOverlaps(repo, &farmer.Farms[i])
+		if err != nil {
+			return err
+		}
+		farmer.Farms[i].Overlaps = overlaps
+	}
+	return nil
+}
+
+// ensureKeptHarvestsFit checks the stored farms sent without harvests, which keep the
+// ones they have, against their new arable area
+func ensureKeptHarvestsFit(existing, farmer *models.Farmer) error {
+	stored := make(map[uint][]models.Harvest, len(existing.Farms))
+	for _, farm := range existing.Farms {
+		stored[farm.ID] = farm.Harvests
+	}
+
+	var errs validation.Errors
+	for i, farm := range farmer.Farms {
+		if farm.ID == 0 || farm.Harvests != nil {
+			continue
+		}
+		ensureHarvestsFit(stored[farm.ID], farm.AgricultureArea, validation.Index("farms", i)+".arableArea", &errs)
+	}
+	return errs.Err()
+}
+
+// ensureFarmerCultures resolves the cultures of the harvests of every farm of the farmer
+// against the catalog
+func ensureFarmerCultures(repo *repository.CultureRepository, farmer *models.Farmer) error {
+	var errs validation.Errors
+	for i := range farmer.Farms {
+		if err := resolveHarvestCultures(repo, farmer.Farms[i].Harvests, validation.Index("farms", i), &errs); err != nil {
+			return err
+		}
+	}
+	return errs.Err()
+}
+
+// ensureFarmerExists returns ErrFarmerNotFound when the farmer does not exist
+func ensureFarmerExists(repo *repository.FarmerRepository, farmerID uint) error {
+	exists, err := repo.Exists(farmerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrFarmerNotFound
+	}
+	return nil
+}
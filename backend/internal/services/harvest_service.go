@@ -0,0 +1,226 @@
+// internal/services/harvest_service.go
+package services
+
+import (
+	"context"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+type HarvestService struct {
+	repo     *repository.HarvestRepository
+	farmRepo *repository.FarmRepository
+	uow      *repository.UnitOfWork
+}
+
+func NewHarvestService(repo *repository.HarvestRepository, farmRepo *repository.FarmRepository, uow *repository.UnitOfWork) *HarvestService {
+	return &HarvestService{
+		repo:     repo,
+		farmRepo: farmRepo,
+		uow:      uow,
+	}
+}
+
+func (s *HarvestService) Create(ctx context.Context, harvest *models.Harvest) (*models.Harvest, error) {
+	if err := harvest.Validate(); err != nil {
+		return nil, err
+	}
+
+	if harvest.FarmID == nil {
+		return nil, ErrFarmNotFound
+	}
+
+	var created *models.Harvest
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		if err := ensureFarmExists(repos.Farms, *harvest.FarmID); err != nil {
+			return err
+		}
+		if harvest.SeasonID != nil {
+			if err := ensureSeasonExists(repos.Seasons, *harvest.SeasonID); err != nil {
+				return err
+			}
+		}
+		if err := ensureHarvestCulture(repos.Cultures, harvest); err != nil {
+			return err
+		}
+		if err := ensurePlantedAreaFits(repos.Farms, harvest); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = repos.Harvests.Create(harvest)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos.Audit, harvestEvent(audit.ActionCreate, created), nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *HarvestService) Update(ctx context.Context, harvest *models.Harvest, ifMatch string) (*models.Harvest, error) {
+	if err := harvest.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Harvest
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Harvests.GetByID(harvest.ID)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, harvest, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Patch applies an RFC 7396 merge patch to the stored harvest, validates the merged
+// result and saves it
+func (s *HarvestService) Patch(ctx context.Context, id uint, patch []byte, ifMatch string) (*models.Harvest, error) {
+	var updated *models.Harvest
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Harvests.GetByID(id)
+		if err != nil {
+			return err
+		}
+
+		var harvest models.Harvest
+		if _, err := applyMergePatch(existing, patch, &harvest); err != nil {
+			return err
+		}
+		harvest.ID = id
+
+		if err := harvest.Validate(); err != nil {
+			return err
+		}
+
+		updated, err = s.update(ctx, repos, existing, &harvest, ifMatch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// update saves harvest over existing, provided ifMatch still matches it
+func (s *HarvestService) update(ctx context.Context, repos *repository.Repositories, existing, harvest *models.Harvest, ifMatch string) (*models.Harvest, error) {
+	if err := checkIfMatch(ifMatch, existing.ETag()); err != nil {
+		return nil, err
+	}
+	harvest.Version = existing.Version
+
+	// Keep the current farm when the request does not move the harvest
+	if harvest.FarmID == nil {
+		harvest.FarmID = existing.FarmID
+	} else if err := ensureFarmExists(repos.Farms, *harvest.FarmID); err != nil {
+		return nil, err
+	}
+
+	if harvest.SeasonID != nil {
+		if err := ensureSeasonExists(repos.Seasons, *harvest.SeasonID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ensureHarvestCulture(repos.Cultures, harvest); err != nil {
+		return nil, err
+	}
+
+	if err := ensurePlantedAreaFits(repos.Farms, harvest); err != nil {
+		return nil, err
+	}
+
+	updated, err := repos.Harvests.Update(harvest)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, repos.Audit, harvestEvent(audit.ActionUpdate, updated), existing, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *HarvestService) Delete(ctx context.Context, id uint, ifMatch string) error {
+	return s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		existing, err := repos.Harvests.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkIfMatch(ifMatch, existing.ETag()); err != nil {
+			return err
+		}
+
+		if err := repos.Harvests.Delete(id); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos.Audit, harvestEvent(audit.ActionDelete, existing), existing, nil)
+	})
+}
+
+func (s *HarvestService) GetByID(id uint) (*models.Harvest, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *HarvestService) GetAllByFarm(farmID uint, params models.PaginationParams) (models.PaginatedResult, error) {
+	if err := ensureFarmExists(s.farmRepo, farmID); err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	params = normalizePagination(params)
+
+	harvests, total, err := s.repo.GetAllByFarm(farmID, params)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	return models.NewPaginatedResult(harvests, total, params), nil
+}
+
+// ensureHarvestCulture resolves the harvest culture against the catalog
+func ensureHarvestCulture(repo *repository.CultureRepository, harvest *models.Harvest) error {
+	var errs validation.Errors
+	if err := resolveCulture(repo, harvest, "", &errs); err != nil {
+		return err
+	}
+	return errs.Err()
+}
+
+// ensurePlantedAreaFits checks that harvest, added to the other harvests of its farm in
+// the same planting period, does not take more than the farm's arable area
+func ensurePlantedAreaFits(repo *repository.FarmRepository, harvest *models.Harvest) error {
+	farm, err := repo.GetByID(*harvest.FarmID)
+	if err != nil {
+		return err
+	}
+
+	planted := make(models.PlantedAreas)
+	for _, other := range farm.Harvests {
+		if other.ID != harvest.ID {
+			planted.Add(other)
+		}
+	}
+
+	if planted.Add(*harvest) > farm.AgricultureArea {
+		var errs validation.Errors
+		errs.Add("plantedArea", validation.CodeExceeded, models.PlantedAreaExceededMessage)
+		return errs.Err()
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// internal/services/map_service.go
+package services
+
+import (
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+type MapService struct {
+	farmRepo    *repository.FarmRepository
+	cultureRepo *repository.CultureRepository
+}
+
+func NewMapService(farmRepo *repository.FarmRepository, cultureRepo *repository.CultureRepository) *MapService {
+	return &MapService{farmRepo: farmRepo, cultureRepo: cultureRepo}
+}
+
+// GetFarms returns the farms as a GeoJSON FeatureCollection. Farms with a centroid but
+// no boundary are drawn as a point; farms with neither are left out by GetEntries.
+func (s *MapService) GetFarms(filter models.MapFilter) (*geo.FeatureCollection, error) {
+	entries, err := s.GetEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &geo.FeatureCollection{Features: make([]geo.Feature, 0, len(entries))}
+	for _, entry := range entries {
+		collection.Features = append(collection.Features, geo.Feature{
+			ID:         entry.ID,
+			Geometry:   entry.Geometry(filter.Centroids, filter.Simplify),
+			Properties: entry,
+		})
+	}
+	return collection, nil
+}
+
+// GetEntries returns the farms with a boundary or a centroid that match the filter,
+// leaving out the ones outside its bounding box
+func (s *MapService) GetEntries(filter models.MapFilter) ([]models.FarmMapEntry, error) {
+	dashboard, err := resolveDashboardFilter(s.cultureRepo, filter.DashboardFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.farmRepo.GetMapEntries(dashboard, filter.BBox)
+}
@@ -0,0 +1,121 @@
+// internal/services/overlap_service.go
+package services
+
+import (
+	"sort"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+// OverlapService finds farms whose boundaries overlap, which usually means a farm was
+// registered twice or its land is disputed
+type OverlapService struct {
+	farmRepo *repository.FarmRepository
+}
+
+func NewOverlapService(farmRepo *repository.FarmRepository) *OverlapService {
+	return &OverlapService{farmRepo: farmRepo}
+}
+
+// GetByFarm lists the farms the boundary of the farm overlaps, largest overlap first.
+// A farm without a boundary overlaps nothing.
+func (s *OverlapService) GetByFarm(id uint) ([]models.FarmOverlap, error) {
+	farm, err := s.farmRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return findOverlaps(s.farmRepo, farm)
+}
+
+// Report lists every pair of farms whose boundaries overlap, largest overlap first
+func (s *OverlapService) Report() ([]models.FarmOverlapPair, error) {
+	farms, err := s.farmRepo.GetBoundaries(nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := make(map[uint]geo.BBox, len(farms))
+	for _, farm := range farms {
+		bounds[farm.ID] = farm.Boundary.Bounds()
+	}
+	sort.SliceStable(farms, func(i, j int) bool {
+		return bounds[farms[i].ID].MinLon < bounds[farms[j].ID].MinLon
+	})
+
+	// Sweep from west to east: a farm can only overlap the ones that start before it ends
+	pairs := []models.FarmOverlapPair{}
+	for i, farm := range farms {
+		for _, other := range farms[i+1:] {
+			if bounds[other.ID].MinLon > bounds[farm.ID].MaxLon {
+				break
+			}
+			if !bounds[farm.ID].Intersects(bounds[other.ID]) {
+				continue
+			}
+
+			area := geo.IntersectionArea(*farm.Boundary, *other.Boundary) / geo.SquareMetersPerHectare
+			if area < models.OverlapMinimumArea {
+				continue
+			}
+
+			first, second := farm, other
+			if first.ID > second.ID {
+				first, second = second, first
+			}
+			pairs = append(pairs, models.FarmOverlapPair{
+				FarmID:        first.ID,
+				FarmName:      first.Name,
+				OtherFarmID:   second.ID,
+				OtherFarmName: second.Name,
+				Area:          area,
+			})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Area != pairs[j].Area {
+			return pairs[i].Area > pairs[j].Area
+		}
+		if pairs[i].FarmID != pairs[j].FarmID {
+			return pairs[i].FarmID < pairs[j].FarmID
+		}
+		return pairs[i].OtherFarmID < pairs[j].OtherFarmID
+	})
+	return pairs, nil
+}
+
+// findOverlaps compares the boundary of the farm with the ones of the farms whose
+// bounding box meets its own
+func findOverlaps(repo *repository.FarmRepository, farm *models.Farm) ([]models.FarmOverlap, error) {
+	overlaps := []models.FarmOverlap{}
+	if farm.Boundary == nil {
+		return overlaps, nil
+	}
+
+	box := farm.Boundary.Bounds()
+	candidates, err := repo.GetBoundaries(&box, farm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		area := geo.IntersectionArea(*farm.Boundary, *candidate.Boundary) / geo.SquareMetersPerHectare
+		if area < models.OverlapMinimumArea {
+			continue
+		}
+
+		overlap := models.FarmOverlap{FarmID: candidate.ID, FarmName: candidate.Name, Area: area}
+		if farm.BoundaryArea != nil && *farm.BoundaryArea > 0 {
+			overlap.Percentage = area / *farm.BoundaryArea * 100
+		}
+		overlaps = append(overlaps, overlap)
+	}
+
+	sort.SliceStable(overlaps, func(i, j int) bool {
+		return overlaps[i].Area > overlaps[j].Area
+	})
+	return overlaps, nil
+}
@@ -0,0 +1,42 @@
+// internal/services/pagination.go
+package services
+
+import (
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/cursor"
+)
+
+// normalizePagination sets default values if not provided
+func normalizePagination(params models.PaginationParams) models.PaginationParams {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	return params
+}
+
+// normalizeCursor sets the default limit if not provided
+func normalizeCursor(params models.CursorParams) models.CursorParams {
+	if params.Limit <= 0 {
+		params.Limit = 10
+	}
+	return params
+}
+
+// newCursorResult builds the response for a keyset page
+func newCursorResult(items interface{}, next, prev *cursor.Cursor, total *int64, params models.CursorParams) models.CursorResult {
+	result := models.CursorResult{
+		Items: items,
+		Limit: params.Limit,
+		Total: total,
+	}
+	if next != nil {
+		result.NextCursor = cursor.Encode(*next)
+	}
+	if prev != nil {
+		result.PrevCursor = cursor.Encode(*prev)
+	}
+	return result
+}
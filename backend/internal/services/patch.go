@@ -0,0 +1,35 @@
+// internal/services/patch.go
+package services
+
+import (
+	"encoding/json"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/mergepatch"
+)
+
+// applyMergePatch applies an RFC 7396 merge patch to the JSON representation of current
+// and decodes the merged document into target. It returns the top-level fields named by
+// the patch so callers can tell which associations it touches.
+func applyMergePatch(current interface{}, patch []byte, target interface{}) (map[string]bool, error) {
+	fields, err := mergepatch.Fields(patch)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrValidation, "patch inválido: o documento deve ser um objeto JSON", err)
+	}
+
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrValidation, "patch inválido: "+err.Error(), err)
+	}
+
+	if err := json.Unmarshal(merged, target); err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrValidation, "patch inválido: "+err.Error(), err)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,19 @@
+// internal/services/precondition.go
+package services
+
+import (
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+	"github.com/samuel-prates/farm-project/backend/pkg/etag"
+)
+
+// ErrStaleVersion is returned when the If-Match sent by the client no longer matches the record
+var ErrStaleVersion = apperrors.PreconditionFailed("o registro foi alterado desde a última leitura")
+
+// checkIfMatch compares the If-Match value sent by the client with the current entity tag.
+// An empty value skips the check.
+func checkIfMatch(ifMatch, current string) error {
+	if etag.MatchStrong(ifMatch, current) {
+		return nil
+	}
+	return ErrStaleVersion
+}
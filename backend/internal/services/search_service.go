@@ -0,0 +1,82 @@
+// internal/services/search_service.go
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/document"
+	"github.com/samuel-prates/farm-project/backend/pkg/search"
+	"github.com/samuel-prates/farm-project/backend/pkg/validation"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+type SearchService struct {
+	repo *repository.SearchRepository
+}
+
+func NewSearchService(repo *repository.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search looks query up in farmer names and documents and in farm names and cities,
+// ignoring case and accents and tolerating typos
+func (s *SearchService) Search(query string, limit int) (models.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		var errs validation.Errors
+		errs.Add("q", validation.CodeRequired, "informe o termo de busca")
+		return models.SearchResult{}, errs.Err()
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	// Only a query made of numbers and punctuation is taken as a document
+	var digits string
+	if !strings.ContainsFunc(query, unicode.IsLetter) {
+		digits = document.Normalize(query)
+	}
+	hits, err := s.repo.Search(strings.Join(terms, " "), search.PrefixQuery(terms), digits, limit)
+	if err != nil {
+		return models.SearchResult{}, err
+	}
+
+	for i := range hits {
+		hits[i].Highlights = highlightHit(hits[i], terms, digits)
+	}
+	return models.SearchResult{Query: query, Items: hits}, nil
+}
+
+// highlightHit marks the matching words of every field the hit was searched on,
+// leaving out the fields without a match
+func highlightHit(hit models.SearchHit, terms []string, digits string) map[string]string {
+	fields := map[string]string{"name": search.Highlight(hit.Name, terms)}
+	switch hit.Type {
+	case models.SearchTypeFarmer:
+		if digits != "" {
+			fields["document"] = search.Highlight(hit.Document, []string{digits})
+		}
+	case models.SearchTypeFarm:
+		fields["city"] = search.Highlight(hit.City, terms)
+	}
+
+	highlights := make(map[string]string)
+	for field, value := range fields {
+		if strings.Contains(value, "<mark>") {
+			highlights[field] = value
+		}
+	}
+	return highlights
+}
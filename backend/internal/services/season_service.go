@@ -0,0 +1,119 @@
+// internal/services/season_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+)
+
+type SeasonService struct {
+	repo *repository.SeasonRepository
+	uow  *repository.UnitOfWork
+}
+
+func NewSeasonService(repo *repository.SeasonRepository, uow *repository.UnitOfWork) *SeasonService {
+	return &SeasonService{
+		repo: repo,
+		uow:  uow,
+	}
+}
+
+// Create saves the season. The overlap check and the insert share a transaction that
+// holds the cycle's lock, so two overlapping seasons created at once cannot both pass.
+func (s *SeasonService) Create(ctx context.Context, season *models.Season) (*models.Season, error) {
+	if err := season.Validate(); err != nil {
+		return nil, err
+	}
+
+	var created *models.Season
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		if err := ensureNoOverlap(repos.Seasons, season); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = repos.Seasons.Create(season)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *SeasonService) Update(ctx context.Context, season *models.Season) (*models.Season, error) {
+	if err := season.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Season
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		if _, err := repos.Seasons.GetByID(season.ID); err != nil {
+			return err
+		}
+		if err := ensureNoOverlap(repos.Seasons, season); err != nil {
+			return err
+		}
+
+		var err error
+		updated, err = repos.Seasons.Update(season)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *SeasonService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *SeasonService) GetByID(id uint) (*models.Season, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *SeasonService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	params = normalizePagination(params)
+
+	seasons, total, err := s.repo.GetAll(params)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	return models.NewPaginatedResult(seasons, total, params), nil
+}
+
+// ensureNoOverlap rejects a season whose dates overlap another season of the same
+// cycle. It locks the cycle first, so it must run in the transaction that saves the season.
+func ensureNoOverlap(repo *repository.SeasonRepository, season *models.Season) error {
+	if err := repo.LockCycle(season.Cycle); err != nil {
+		return err
+	}
+
+	other, err := repo.FindOverlapping(season)
+	if err != nil {
+		return err
+	}
+	if other != nil {
+		return apperrors.Conflict(fmt.Sprintf("o período se sobrepõe à temporada %q, do mesmo ciclo", other.Name))
+	}
+	return nil
+}
+
+func ensureSeasonExists(repo *repository.SeasonRepository, seasonID uint) error {
+	exists, err := repo.Exists(seasonID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSeasonNotFound
+	}
+	return nil
+}
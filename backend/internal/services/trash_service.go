@@ -0,0 +1,84 @@
+// internal/services/trash_service.go
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"github.com/samuel-prates/farm-project/backend/pkg/audit"
+	"github.com/samuel-prates/farm-project/backend/pkg/logger"
+)
+
+type TrashService struct {
+	repo *repository.TrashRepository
+	uow  *repository.UnitOfWork
+}
+
+func NewTrashService(repo *repository.TrashRepository, uow *repository.UnitOfWork) *TrashService {
+	return &TrashService{
+		repo: repo,
+		uow:  uow,
+	}
+}
+
+func (s *TrashService) GetAll(params models.PaginationParams) (models.PaginatedResult, error) {
+	params = normalizePagination(params)
+
+	items, total, err := s.repo.GetAll(params)
+	if err != nil {
+		return models.PaginatedResult{}, err
+	}
+
+	return models.NewPaginatedResult(items, total, params), nil
+}
+
+// RestoreFarmer brings a deleted farmer back with the farms and harvests deleted with it
+func (s *TrashService) RestoreFarmer(ctx context.Context, id uint) (*models.Farmer, error) {
+	var restored *models.Farmer
+	err := s.uow.DoContext(ctx, func(repos *repository.Repositories) error {
+		var err error
+		restored, err = repos.Farmers.Restore(id)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, repos.Audit, farmerEvent(audit.ActionRestore, restored), nil, restored); err != nil {
+			return err
+		}
+		return recordFarmsAudit(ctx, repos.Audit, audit.ActionRestore, restored.Farms)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// Purge permanently removes the records that have been in the trash longer than retention
+func (s *TrashService) Purge(retention time.Duration) (int64, error) {
+	return s.repo.Purge(time.Now().Add(-retention))
+}
+
+// RunPurgeJob purges the trash every interval until ctx is cancelled
+func (s *TrashService) RunPurgeJob(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.Purge(retention)
+			if err != nil {
+				logger.Error("Erro ao limpar a lixeira: %v", err)
+				continue
+			}
+			if purged > 0 {
+				logger.Info("Lixeira limpa: %d registros removidos definitivamente", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// pkg/apperrors/apperrors.go
+package apperrors
+
+import "errors"
+
+// Sentinel kinds shared by the repository, service and handler layers.
+// Use errors.Is(err, apperrors.ErrNotFound) to test the kind of an error.
+var (
+	// ErrNotFound means the requested record does not exist
+	ErrNotFound = errors.New("registro não encontrado")
+	// ErrConflict means the operation clashes with an existing record (e.g. duplicated document)
+	ErrConflict = errors.New("conflito com registro existente")
+	// ErrValidation means the input failed business validation
+	ErrValidation = errors.New("dados inválidos")
+	// ErrUnavailable means a dependency such as the database could not be reached
+	ErrUnavailable = errors.New("serviço temporariamente indisponível")
+	// ErrPreconditionFailed means the record changed since the version the client holds
+	ErrPreconditionFailed = errors.New("o registro foi alterado por outra requisição")
+)
+
+// Error is a typed error carrying one of the sentinel kinds, a user-facing message
+// and, optionally, the underlying cause
+type Error struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+// New creates an Error of the given kind
+func New(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates an Error of the given kind that keeps the original cause
+func Wrap(kind error, message string, cause error) *Error {
+	return &Error{Kind: kind, Message: message, Err: cause}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Kind.Error()
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// NotFound creates an ErrNotFound error
+func NotFound(message string) *Error {
+	return New(ErrNotFound, message)
+}
+
+// Conflict creates an ErrConflict error
+func Conflict(message string) *Error {
+	return New(ErrConflict, message)
+}
+
+// Validation creates an ErrValidation error
+func Validation(message string) *Error {
+	return New(ErrValidation, message)
+}
+
+// Unavailable creates an ErrUnavailable error
+func Unavailable(message string) *Error {
+	return New(ErrUnavailable, message)
+}
+
+// PreconditionFailed creates an ErrPreconditionFailed error
+func PreconditionFailed(message string) *Error {
+	return New(ErrPreconditionFailed, message)
+}
@@ -0,0 +1,110 @@
+// pkg/audit/audit.go
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// Actions recorded in the audit trail
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+)
+
+// Metadata identifies who made a change and in which request
+type Metadata struct {
+	Actor     string
+	RequestID string
+}
+
+type contextKey struct{}
+
+// WithMetadata returns a copy of ctx carrying the audit metadata
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, contextKey{}, metadata)
+}
+
+// FromContext returns the audit metadata stored in ctx, or an empty Metadata
+func FromContext(ctx context.Context) Metadata {
+	metadata, _ := ctx.Value(contextKey{}).(Metadata)
+	return metadata
+}
+
+// Change holds the previous and the new value of a field
+type Change struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+// Diff compares two JSON objects field by field and returns the fields whose values
+// differ, treating a missing field as null. Either side may be empty, as happens for
+// create and delete events. Fields listed in ignore are left out of the result.
+func Diff(before, after []byte, ignore ...string) (map[string]Change, error) {
+	beforeFields, err := decodeObject(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := decodeObject(after)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(ignore))
+	for _, field := range ignore {
+		skip[field] = true
+	}
+
+	changes := make(map[string]Change)
+	for field, from := range beforeFields {
+		if skip[field] {
+			continue
+		}
+		to, ok := afterFields[field]
+		if (!ok && !isNull(from)) || (ok && !sameValue(from, to)) {
+			changes[field] = Change{From: from, To: to}
+		}
+	}
+	for field, to := range afterFields {
+		if skip[field] {
+			continue
+		}
+		if _, ok := beforeFields[field]; !ok && !isNull(to) {
+			changes[field] = Change{To: to}
+		}
+	}
+
+	return changes, nil
+}
+
+func decodeObject(data []byte) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(data)) == 0 || isNull(data) {
+		return fields, nil
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func isNull(value json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
+
+// sameValue compares two JSON values ignoring formatting and key order
+func sameValue(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+
+	var left, right interface{}
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
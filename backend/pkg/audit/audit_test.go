@@ -0,0 +1,89 @@
+// pkg/audit/audit_test.go
+package audit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  string
+		after   string
+		ignore  []string
+		changed []string
+	}{
+		{
+			name:    "Create",
+			before:  "",
+			after:   `{"farmName":"Boa Vista","totalArea":100}`,
+			changed: []string{"farmName", "totalArea"},
+		},
+		{
+			name:    "Delete",
+			before:  `{"farmName":"Boa Vista"}`,
+			after:   "null",
+			changed: []string{"farmName"},
+		},
+		{
+			name:    "Update",
+			before:  `{"farmName":"Boa Vista","totalArea":100,"city":"Campinas"}`,
+			after:   `{"city":"Campinas","farmName":"Boa Vista","totalArea":120}`,
+			changed: []string{"totalArea"},
+		},
+		{
+			name:    "Nested Values Compared Semantically",
+			before:  `{"harvests":[{"year":2024, "culture":"Soja"}]}`,
+			after:   `{"harvests":[{"culture":"Soja","year":2024}]}`,
+			changed: nil,
+		},
+		{
+			name:    "Missing Field Equals Null",
+			before:  "",
+			after:   `{"farmName":"Boa Vista","harvests":null}`,
+			changed: []string{"farmName"},
+		},
+		{
+			name:    "Ignored Fields",
+			before:  `{"farmName":"A","updated_at":"2024-01-01T00:00:00Z"}`,
+			after:   `{"farmName":"A","updated_at":"2024-02-01T00:00:00Z"}`,
+			ignore:  []string{"updated_at"},
+			changed: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Diff([]byte(tt.before), []byte(tt.after), tt.ignore...)
+			if err != nil {
+				t.Fatalf("Diff returned error: %v", err)
+			}
+			if len(changes) != len(tt.changed) {
+				t.Fatalf("Diff returned %d changes, want %d: %v", len(changes), len(tt.changed), changes)
+			}
+			for _, field := range tt.changed {
+				if _, ok := changes[field]; !ok {
+					t.Errorf("Diff did not report field %q", field)
+				}
+			}
+		})
+	}
+}
+
+func TestDiff_InvalidJSON(t *testing.T) {
+	if _, err := Diff([]byte(`{"a":`), nil); err == nil {
+		t.Error("Diff should fail on invalid JSON")
+	}
+}
+
+func TestMetadataContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != (Metadata{}) {
+		t.Errorf("FromContext on empty context = %+v, want zero value", got)
+	}
+
+	ctx := WithMetadata(context.Background(), Metadata{Actor: "maria", RequestID: "abc"})
+	if got := FromContext(ctx); got.Actor != "maria" || got.RequestID != "abc" {
+		t.Errorf("FromContext = %+v, want actor maria and request abc", got)
+	}
+}
@@ -3,11 +3,15 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	DatabaseURL string
-	Port        string
+	DatabaseURL        string
+	Port               string
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
 }
 
 func LoadConfig() *Config {
@@ -22,8 +26,21 @@ func LoadConfig() *Config {
 		port = "8080"
 	}
 
+	// Registros na lixeira são removidos definitivamente após o período de retenção
+	retentionDays := 30
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && days > 0 {
+		retentionDays = days
+	}
+
+	purgeInterval := time.Hour
+	if interval, err := time.ParseDuration(os.Getenv("TRASH_PURGE_INTERVAL")); err == nil && interval > 0 {
+		purgeInterval = interval
+	}
+
 	return &Config{
-		DatabaseURL: databaseURL,
-		Port:        port,
+		DatabaseURL:        databaseURL,
+		Port:               port,
+		TrashRetention:     time.Duration(retentionDays) * 24 * time.Hour,
+		TrashPurgeInterval: purgeInterval,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,44 @@
+// pkg/cursor/cursor.go
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalid is returned when a cursor token cannot be decoded
+var ErrInvalid = errors.New("cursor inválido")
+
+// Cursor marks a position in a list ordered by ID. A forward cursor reads the rows
+// after ID, a backward one the rows before it. The zero value is the start of the list.
+type Cursor struct {
+	ID       uint `json:"id"`
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode returns the opaque token handed to clients
+func Encode(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a token produced by Encode. An empty token is the start of the list.
+func Decode(token string) (Cursor, error) {
+	var c Cursor
+	if token == "" {
+		return c, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, ErrInvalid
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalid
+	}
+	if c.Backward && c.ID == 0 {
+		return Cursor{}, ErrInvalid
+	}
+	return c, nil
+}
@@ -0,0 +1,52 @@
+// pkg/cursor/cursor_test.go
+package cursor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []Cursor{
+		{},
+		{ID: 42},
+		{ID: 7, Backward: true},
+	}
+
+	for _, c := range tests {
+		token := Encode(c)
+		decoded, err := Decode(token)
+		if err != nil {
+			t.Fatalf("Decode(%q) returned error: %v", token, err)
+		}
+		if decoded != c {
+			t.Errorf("Decode(Encode(%+v)) = %+v", c, decoded)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		expected Cursor
+		err      error
+	}{
+		{name: "Empty Token", token: "", expected: Cursor{}},
+		{name: "Not Base64", token: "%%%", err: ErrInvalid},
+		{name: "Not JSON", token: "bm90LWpzb24", err: ErrInvalid},
+		{name: "Backward Without ID", token: Encode(Cursor{Backward: true}), err: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Decode(tt.token)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if c != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// pkg/database/boundaries.go
+package database
+
+import (
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// migrateBoundaryMeasures fills in the bounding box and the centroid of the boundaries
+// saved before they were kept, which the overlap checks and the nearby search rely on
+func migrateBoundaryMeasures(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var farms []models.Farm
+		if err := tx.Unscoped().
+			Select("id, boundary").
+			Where("boundary IS NOT NULL AND (boundary_min_lon IS NULL OR centroid_lat IS NULL)").
+			Find(&farms).Error; err != nil {
+			return err
+		}
+
+		for _, farm := range farms {
+			farm.MeasureBoundary()
+			if err := tx.Unscoped().
+				Model(&models.Farm{}).
+				Where("id = ?", farm.ID).
+				UpdateColumns(map[string]interface{}{
+					"boundary_min_lon": farm.BoundaryMinLon,
+					"boundary_min_lat": farm.BoundaryMinLat,
+					"boundary_max_lon": farm.BoundaryMaxLon,
+					"boundary_max_lat": farm.BoundaryMaxLat,
+					"centroid_lat":     farm.CentroidLat,
+					"centroid_lon":     farm.CentroidLon,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+// pkg/database/cultures.go
+package database
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// defaultCultures seeds an empty catalog with the most common Brazilian crops
+var defaultCultures = []models.Culture{
+	{Name: "Soja", Category: models.CategoryGrao, DefaultUnit: models.UnitSacas, Aliases: aliases("Soybean", "Soy")},
+	{Name: "Milho", Category: models.CategoryGrao, DefaultUnit: models.UnitSacas, Aliases: aliases("Corn", "Maize")},
+	{Name: "Café", Category: models.CategoryGrao, DefaultUnit: models.UnitSacas, Aliases: aliases("Coffee")},
+	{Name: "Trigo", Category: models.CategoryGrao, DefaultUnit: models.UnitSacas, Aliases: aliases("Wheat")},
+	{Name: "Feijão", Category: models.CategoryGrao, DefaultUnit: models.UnitSacas, Aliases: aliases("Beans")},
+	{Name: "Arroz", Category: models.CategoryGrao, DefaultUnit: models.UnitSacas, Aliases: aliases("Rice")},
+	{Name: "Algodão", Category: models.CategoryFibra, DefaultUnit: models.UnitToneladas, Aliases: aliases("Cotton")},
+	{Name: "Laranja", Category: models.CategoryFruta, DefaultUnit: models.UnitToneladas, Aliases: aliases("Orange")},
+	{Name: "Cana-de-açúcar", Category: models.CategoryOutra, DefaultUnit: models.UnitToneladas, Aliases: aliases("Cana", "Sugarcane")},
+}
+
+func aliases(names ...string) []models.CultureAlias {
+	result := make([]models.CultureAlias, len(names))
+	for i, name := range names {
+		result[i] = models.CultureAlias{Name: name}
+	}
+	return result
+}
+
+// migrateCultures seeds an empty catalog and links the harvests saved before the
+// catalog existed. Legacy names are matched against the catalog names and aliases;
+// the ones that match nothing become new cultures, so that "Girassol" and
+// "GIRASSOL " end up as a single entry.
+func migrateCultures(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		cultures := repository.NewCultureRepository(tx)
+
+		var count int64
+		if err := tx.Model(&models.Culture{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			for _, seed := range defaultCultures {
+				culture := seed
+				culture.Aliases = append([]models.CultureAlias(nil), seed.Aliases...)
+				culture.Normalize()
+				if _, err := cultures.Create(&culture); err != nil {
+					return err
+				}
+			}
+		}
+
+		var names []string
+		if err := tx.Unscoped().
+			Model(&models.Harvest{}).
+			Where("culture_id IS NULL").
+			Distinct().
+			Pluck("culture", &names).Error; err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			normalized := models.NormalizeCultureName(name)
+			if normalized == "" {
+				continue
+			}
+
+			culture, err := cultures.FindByNormalizedName(normalized)
+			if err != nil {
+				return err
+			}
+			if culture == nil {
+				culture = &models.Culture{Name: legacyCultureName(name), Category: models.CategoryOutra}
+				culture.Normalize()
+				if culture, err = cultures.Create(culture); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Unscoped().
+				Model(&models.Harvest{}).
+				Where("culture_id IS NULL AND culture = ?", name).
+				Updates(map[string]interface{}{"culture_id": culture.ID, "culture": culture.Name}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// legacyCultureName turns a free-text culture such as "GIRASSOL " into "Girassol"
+func legacyCultureName(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(first)) + name[size:]
+}
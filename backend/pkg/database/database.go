@@ -7,17 +7,56 @@ import (
 	"gorm.io/gorm"
 )
 
+// searchSetup enables the extensions used by the search endpoint. unaccent is not
+// immutable, so it is wrapped in f_unaccent to be usable in the trigram indexes.
+var searchSetup = []string{
+	"CREATE EXTENSION IF NOT EXISTS unaccent",
+	"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+	`CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text
+		LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+		AS $$ SELECT public.unaccent('public.unaccent', $1) $$`,
+	"CREATE INDEX IF NOT EXISTS idx_farmers_name_trgm ON farmers USING gin (f_unaccent(lower(name)) gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_farms_name_trgm ON farms USING gin (f_unaccent(lower(name)) gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_farms_city_trgm ON farms USING gin (f_unaccent(lower(city)) gin_trgm_ops)",
+}
+
 func Connect(dsn string) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := dropLegacyDocumentConstraints(db); err != nil {
+		return nil, err
+	}
+
 	// Auto Migrate the models
-	err = db.AutoMigrate(&models.Farmer{}, &models.Farm{}, &models.Harvest{})
+	err = db.AutoMigrate(&models.Farmer{}, &models.Farm{}, &models.Season{}, &models.Culture{}, &models.CultureAlias{}, &models.Harvest{}, &models.AuditEvent{})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := migrateFarmerDocuments(db); err != nil {
+		return nil, err
+	}
+
+	if err := db.Exec(activeDocumentIndex).Error; err != nil {
+		return nil, err
+	}
+
+	if err := migrateCultures(db); err != nil {
+		return nil, err
+	}
+
+	if err := migrateBoundaryMeasures(db); err != nil {
+		return nil, err
+	}
+
+	for _, statement := range searchSetup {
+		if err := db.Exec(statement).Error; err != nil {
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
@@ -0,0 +1,122 @@
+// pkg/database/farmers.go
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/document"
+	"gorm.io/gorm"
+)
+
+// activeDocumentIndex keeps the documents of the active farmers unique. Farmers in the
+// trash are left out, so a deleted farmer's document can be registered again.
+const activeDocumentIndex = `CREATE UNIQUE INDEX IF NOT EXISTS idx_farmers_federal_identification_active
+	ON farmers (federal_identification) WHERE deleted_at IS NULL`
+
+// legacyDocumentConstraints are the names the unique constraint on the farmer document
+// was created with when it still covered the farmers in the trash
+var legacyDocumentConstraints = []string{"uni_farmers_federal_identification", "farmers_federal_identification_key"}
+
+// dropLegacyDocumentConstraints removes the old unique constraint on the farmer
+// document. It runs before AutoMigrate, which would otherwise try to drop it by a
+// single name and fail on databases that use the other one.
+func dropLegacyDocumentConstraints(db *gorm.DB) error {
+	for _, name := range legacyDocumentConstraints {
+		if err := db.Exec("ALTER TABLE IF EXISTS farmers DROP CONSTRAINT IF EXISTS " + name).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateFarmerDocuments rewrites the documents saved before they were normalized to
+// digits only and fills in their kind. It runs before activeDocumentIndex is created
+// and fails, changing nothing, when two active farmers end up with the same document,
+// so that the duplicates are merged or deleted by hand instead of breaking the index.
+func migrateFarmerDocuments(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var farmers []models.Farmer
+		if err := tx.Unscoped().
+			Select("id, federal_identification, document_kind, deleted_at").
+			Order("id").
+			Find(&farmers).Error; err != nil {
+			return err
+		}
+
+		if err := duplicateDocuments(farmers); err != nil {
+			return err
+		}
+
+		for _, farmer := range farmers {
+			number, kind := legacyDocument(farmer.FederalIdentification)
+			if number == farmer.FederalIdentification && kind == farmer.DocumentKind {
+				continue
+			}
+
+			if err := tx.Unscoped().
+				Model(&models.Farmer{}).
+				Where("id = ?", farmer.ID).
+				UpdateColumns(map[string]interface{}{
+					"federal_identification": number,
+					"document_kind":          kind,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// legacyDocument returns the digits-only form of a stored document and its kind. The
+// kind is left empty for documents that are neither a CPF nor a CNPJ, and a document
+// without any digit is kept as it is rather than emptied.
+func legacyDocument(value string) (string, document.Kind) {
+	number := document.Normalize(value)
+	if number == "" {
+		return value, ""
+	}
+
+	switch len(number) {
+	case 11:
+		return number, document.KindPF
+	case 14:
+		return number, document.KindPJ
+	default:
+		return number, ""
+	}
+}
+
+// duplicateDocuments reports the active farmers whose documents are the same once
+// normalized, such as "123.456.789-09" and "12345678909"
+func duplicateDocuments(farmers []models.Farmer) error {
+	owners := make(map[string][]uint)
+	for _, farmer := range farmers {
+		if farmer.DeletedAt.Valid {
+			continue
+		}
+		number, _ := legacyDocument(farmer.FederalIdentification)
+		owners[number] = append(owners[number], farmer.ID)
+	}
+
+	var duplicates []string
+	for number, ids := range owners {
+		if len(ids) < 2 {
+			continue
+		}
+		list := make([]string, len(ids))
+		for i, id := range ids {
+			list[i] = fmt.Sprint(id)
+		}
+		duplicates = append(duplicates, fmt.Sprintf("%s (fazendeiros %s)", number, strings.Join(list, ", ")))
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	sort.Strings(duplicates)
+	return fmt.Errorf("documentos repetidos entre os fazendeiros ativos após a normalização: %s", strings.Join(duplicates, "; "))
+}
@@ -0,0 +1,75 @@
+// pkg/database/farmers_test.go
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samuel-prates/farm-project/backend/internal/models"
+	"github.com/samuel-prates/farm-project/backend/pkg/document"
+	"gorm.io/gorm"
+)
+
+func TestLegacyDocument(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		value        string
+		expectedNum  string
+		expectedKind document.Kind
+	}{
+		{"CPF digits only", "52998224725", "52998224725", document.KindPF},
+		{"CPF with punctuation", "123.456.789-09", "12345678909", document.KindPF},
+		{"CNPJ with punctuation", "12.345.678/0001-95", "12345678000195", document.KindPJ},
+		{"Wrong length", "1234-567", "1234567", ""},
+		{"No digits", "n/a", "n/a", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, kind := legacyDocument(tt.value)
+			if number != tt.expectedNum || kind != tt.expectedKind {
+				t.Errorf("legacyDocument(%q) = %q, %q, want %q, %q", tt.value, number, kind, tt.expectedNum, tt.expectedKind)
+			}
+		})
+	}
+}
+
+func TestDuplicateDocuments(t *testing.T) {
+	deleted := gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	t.Run("Same Document Once Normalized", func(t *testing.T) {
+		err := duplicateDocuments([]models.Farmer{
+			{ID: 1, FederalIdentification: "123.456.789-09"},
+			{ID: 2, FederalIdentification: "52998224725"},
+			{ID: 3, FederalIdentification: "12345678909"},
+		})
+		if err == nil {
+			t.Fatal("Expected the duplicated document to be reported")
+		}
+		expected := "documentos repetidos entre os fazendeiros ativos após a normalização: 12345678909 (fazendeiros 1, 3)"
+		if err.Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, err.Error())
+		}
+	})
+
+	t.Run("Farmer In The Trash", func(t *testing.T) {
+		err := duplicateDocuments([]models.Farmer{
+			{ID: 1, FederalIdentification: "123.456.789-09", DeletedAt: deleted},
+			{ID: 2, FederalIdentification: "12345678909"},
+		})
+		if err != nil {
+			t.Errorf("Expected the deleted farmer to be left out, got %v", err)
+		}
+	})
+
+	t.Run("Distinct Documents", func(t *testing.T) {
+		err := duplicateDocuments([]models.Farmer{
+			{ID: 1, FederalIdentification: "123.456.789-09"},
+			{ID: 2, FederalIdentification: "12.345.678/0001-95"},
+		})
+		if err != nil {
+			t.Errorf("Expected no duplicates, got %v", err)
+		}
+	})
+}
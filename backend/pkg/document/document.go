@@ -0,0 +1,141 @@
+// pkg/document/document.go
+package document
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kind identifies whether a federal document belongs to a person or a company
+type Kind string
+
+const (
+	// KindPF is an individual taxpayer (CPF, 11 digits)
+	KindPF Kind = "PF"
+	// KindPJ is a legal entity (CNPJ, 14 digits)
+	KindPJ Kind = "PJ"
+)
+
+const (
+	cpfLength  = 11
+	cnpjLength = 14
+)
+
+var (
+	// ErrEmpty is returned when no document is informed
+	ErrEmpty = errors.New("documento é obrigatório")
+	// ErrInvalidCharacters is returned when the document has anything other than digits and punctuation
+	ErrInvalidCharacters = errors.New("documento deve conter apenas dígitos")
+	// ErrInvalidLength is returned when the document is neither a CPF nor a CNPJ
+	ErrInvalidLength = errors.New("CPF deve conter 11 dígitos / CNPJ deve conter 14 dígitos")
+	// ErrRepeatedDigits is returned for sequences such as 111.111.111-11
+	ErrRepeatedDigits = errors.New("documento não pode ser uma sequência de dígitos repetidos")
+	// ErrInvalidCheckDigits is returned when the verification digits do not match
+	ErrInvalidCheckDigits = errors.New("dígitos verificadores do documento são inválidos")
+)
+
+// Document is a validated CPF or CNPJ in canonical (digits only) form
+type Document struct {
+	Number string
+	Kind   Kind
+}
+
+// Parse validates a CPF or CNPJ, accepting the usual punctuation
+// ("123.456.789-09", "12.345.678/0001-95"), and returns its canonical form
+func Parse(value string) (Document, error) {
+	digits, err := digitsOf(value)
+	if err != nil {
+		return Document{}, err
+	}
+
+	var kind Kind
+	switch len(digits) {
+	case cpfLength:
+		kind = KindPF
+	case cnpjLength:
+		kind = KindPJ
+	default:
+		return Document{}, ErrInvalidLength
+	}
+
+	if strings.Count(digits, digits[:1]) == len(digits) {
+		return Document{}, ErrRepeatedDigits
+	}
+
+	if !hasValidCheckDigits(digits, kind) {
+		return Document{}, ErrInvalidCheckDigits
+	}
+
+	return Document{Number: digits, Kind: kind}, nil
+}
+
+// Validate reports whether value is a valid CPF or CNPJ
+func Validate(value string) error {
+	_, err := Parse(value)
+	return err
+}
+
+// Normalize strips the punctuation from a document without validating it
+func Normalize(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// digitsOf removes the accepted punctuation and rejects any other character
+func digitsOf(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", ErrEmpty
+	}
+
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '.' || r == '-' || r == '/' || r == ' ':
+			continue
+		default:
+			return "", ErrInvalidCharacters
+		}
+	}
+
+	if b.Len() == 0 {
+		return "", ErrInvalidCharacters
+	}
+	return b.String(), nil
+}
+
+func hasValidCheckDigits(digits string, kind Kind) bool {
+	var firstWeights, secondWeights []int
+	if kind == KindPF {
+		firstWeights = []int{10, 9, 8, 7, 6, 5, 4, 3, 2}
+		secondWeights = []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+	} else {
+		firstWeights = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+		secondWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	}
+
+	first := checkDigit(digits, firstWeights)
+	second := checkDigit(digits, secondWeights)
+	n := len(digits)
+	return int(digits[n-2]-'0') == first && int(digits[n-1]-'0') == second
+}
+
+// checkDigit computes a mod-11 verification digit over the leading len(weights) digits
+func checkDigit(digits string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(digits[i]-'0') * w
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
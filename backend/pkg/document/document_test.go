@@ -0,0 +1,49 @@
+// pkg/document/document_test.go
+package document
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name         string
+		value        string
+		expectedNum  string
+		expectedKind Kind
+		expectedErr  error
+	}{
+		{"CPF digits only", "52998224725", "52998224725", KindPF, nil},
+		{"CPF with punctuation", "123.456.789-09", "12345678909", KindPF, nil},
+		{"CNPJ digits only", "11222333000181", "11222333000181", KindPJ, nil},
+		{"CNPJ with punctuation", "12.345.678/0001-95", "12345678000195", KindPJ, nil},
+		{"Empty", "  ", "", "", ErrEmpty},
+		{"Letters", "abcdefghijk", "", "", ErrInvalidCharacters},
+		{"Mixed letters", "529.982.247-2X", "", "", ErrInvalidCharacters},
+		{"Wrong length", "1234567890", "", "", ErrInvalidLength},
+		{"Repeated CPF", "111.111.111-11", "", "", ErrRepeatedDigits},
+		{"Repeated CNPJ", "00000000000000", "", "", ErrRepeatedDigits},
+		{"Wrong CPF check digits", "529.982.247-26", "", "", ErrInvalidCheckDigits},
+		{"Wrong CNPJ check digits", "12.345.678/0001-96", "", "", ErrInvalidCheckDigits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(tt.value)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Parse(%q) returned error %v, want %v", tt.value, err, tt.expectedErr)
+			}
+			if doc.Number != tt.expectedNum || doc.Kind != tt.expectedKind {
+				t.Errorf("Parse(%q) = %+v, want number %q kind %q", tt.value, doc, tt.expectedNum, tt.expectedKind)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("12.345.678/0001-95"); got != "12345678000195" {
+		t.Errorf("Normalize returned %q", got)
+	}
+}
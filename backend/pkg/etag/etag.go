@@ -0,0 +1,52 @@
+// pkg/etag/etag.go
+package etag
+
+import "strings"
+
+// MatchStrong reports whether an If-Match header value matches the current entity tag
+// using the strong comparison of RFC 9110: weak tags never match. An empty header
+// matches, so the precondition is optional for clients that do not send it.
+func MatchStrong(header, current string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return true
+	}
+
+	for _, tag := range splitTags(header) {
+		if !strings.HasPrefix(tag, "W/") && tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchWeak reports whether an If-None-Match header value matches the current entity tag
+// using the weak comparison of RFC 9110. An empty header never matches.
+func MatchWeak(header, current string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	current = strings.TrimPrefix(current, "W/")
+	for _, tag := range splitTags(header) {
+		if strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTags(header string) []string {
+	parts := strings.Split(header, ",")
+	tags := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if tag := strings.TrimSpace(part); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
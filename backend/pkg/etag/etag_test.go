@@ -0,0 +1,53 @@
+// pkg/etag/etag_test.go
+package etag
+
+import "testing"
+
+func TestMatchStrong(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		current string
+		want    bool
+	}{
+		{"Empty Header", "", `"abc"`, true},
+		{"Wildcard", "*", `"abc"`, true},
+		{"Same Tag", `"abc"`, `"abc"`, true},
+		{"Different Tag", `"abc"`, `"def"`, false},
+		{"Tag In List", `"xyz", "abc"`, `"abc"`, true},
+		{"Weak Tag Never Matches", `W/"abc"`, `"abc"`, false},
+		{"Unquoted Tag", `abc`, `"abc"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchStrong(tt.header, tt.current); got != tt.want {
+				t.Errorf("MatchStrong(%q, %q) = %v, want %v", tt.header, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchWeak(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		current string
+		want    bool
+	}{
+		{"Empty Header", "", `"abc"`, false},
+		{"Wildcard", "*", `"abc"`, true},
+		{"Same Tag", `"abc"`, `"abc"`, true},
+		{"Different Tag", `"abc"`, `"def"`, false},
+		{"Weak Tag Matches", `W/"abc"`, `"abc"`, true},
+		{"Tag In List", `"xyz",W/"abc"`, `"abc"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchWeak(tt.header, tt.current); got != tt.want {
+				t.Errorf("MatchWeak(%q, %q) = %v, want %v", tt.header, tt.current, got, tt.want)
+			}
+		})
+	}
+}
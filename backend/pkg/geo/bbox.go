@@ -0,0 +1,67 @@
+// pkg/geo/bbox.go
+package geo
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidBBox is returned for bounding boxes that are not four valid coordinates
+// with the minimums before the maximums
+var ErrInvalidBBox = errors.New("bbox deve ser minLon,minLat,maxLon,maxLat com coordenadas válidas")
+
+// BBox is an axis-aligned bounding box in degrees
+type BBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// ParseBBox reads a bounding box in the GeoJSON order "minLon,minLat,maxLon,maxLat"
+func ParseBBox(raw string) (BBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return BBox{}, ErrInvalidBBox
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return BBox{}, ErrInvalidBBox
+		}
+		values[i] = value
+	}
+
+	box := BBox{MinLon: values[0], MinLat: values[1], MaxLon: values[2], MaxLat: values[3]}
+	if !validPosition(box.MinLon, box.MinLat) || !validPosition(box.MaxLon, box.MaxLat) ||
+		box.MinLon > box.MaxLon || box.MinLat > box.MaxLat {
+		return BBox{}, ErrInvalidBBox
+	}
+	return box, nil
+}
+
+// Intersects reports whether the boxes share at least a point
+func (b BBox) Intersects(other BBox) bool {
+	return b.MinLon <= other.MaxLon && other.MinLon <= b.MaxLon &&
+		b.MinLat <= other.MaxLat && other.MinLat <= b.MaxLat
+}
+
+// Bounds returns the smallest box holding every position of the geometry
+func (g Geometry) Bounds() BBox {
+	box := BBox{MinLon: math.Inf(1), MinLat: math.Inf(1), MaxLon: math.Inf(-1), MaxLat: math.Inf(-1)}
+	for _, polygon := range g.Polygons {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				box.MinLon = math.Min(box.MinLon, p.Lon())
+				box.MinLat = math.Min(box.MinLat, p.Lat())
+				box.MaxLon = math.Max(box.MaxLon, p.Lon())
+				box.MaxLat = math.Max(box.MaxLat, p.Lat())
+			}
+		}
+	}
+	return box
+}
@@ -0,0 +1,45 @@
+// pkg/geo/distance.go
+package geo
+
+import "math"
+
+// MeanEarthRadius is the IUGG mean radius in meters used by the distance calculations
+const MeanEarthRadius = 6371008.8
+
+// Distance returns the great-circle distance in meters between two positions
+// (haversine formula)
+func Distance(a, b Position) float64 {
+	dLat := radians(b.Lat() - a.Lat())
+	dLon := radians(b.Lon() - a.Lon())
+
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(radians(a.Lat()))*math.Cos(radians(b.Lat()))*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * MeanEarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Around returns a box holding every position within radius meters of center. When
+// the circle reaches a pole or crosses the antimeridian the box spans every longitude.
+func Around(center Position, radius float64) BBox {
+	angle := radius / MeanEarthRadius
+	box := BBox{
+		MinLon: -180,
+		MinLat: center.Lat() - degrees(angle),
+		MaxLon: 180,
+		MaxLat: center.Lat() + degrees(angle),
+	}
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat, box.MaxLat = math.Max(box.MinLat, -90), math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	// the circle is widest in longitude at a latitude nearer the pole than its center
+	span := degrees(math.Asin(math.Sin(angle) / math.Cos(radians(center.Lat()))))
+	if center.Lon()-span >= -180 && center.Lon()+span <= 180 {
+		box.MinLon, box.MaxLon = center.Lon()-span, center.Lon()+span
+	}
+	return box
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,37 @@
+// pkg/geo/feature.go
+package geo
+
+import "encoding/json"
+
+// Feature is a GeoJSON Feature. Geometry is a *Geometry or a Point; Properties is
+// encoded as the feature's properties object.
+type Feature struct {
+	ID         interface{}
+	Geometry   interface{}
+	Properties interface{}
+}
+
+func (f Feature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type       string      `json:"type"`
+		ID         interface{} `json:"id,omitempty"`
+		Geometry   interface{} `json:"geometry"`
+		Properties interface{} `json:"properties"`
+	}{"Feature", f.ID, f.Geometry, f.Properties})
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection
+type FeatureCollection struct {
+	Features []Feature
+}
+
+func (c FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := c.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+	}{"FeatureCollection", features})
+}
@@ -0,0 +1,231 @@
+// pkg/geo/geo.go
+package geo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// EarthRadius is the WGS84 equatorial radius in meters used by the area calculations
+const EarthRadius = 6378137.0
+
+// SquareMetersPerHectare converts areas in square meters to hectares
+const SquareMetersPerHectare = 10000.0
+
+// GeoJSON geometry types accepted as a boundary
+const (
+	TypePolygon      = "Polygon"
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+var (
+	// ErrUnsupportedType is returned for geometries other than Polygon and MultiPolygon
+	ErrUnsupportedType = errors.New("geometria deve ser um Polygon ou MultiPolygon")
+	// ErrEmpty is returned for a geometry without any polygon
+	ErrEmpty = errors.New("geometria não possui coordenadas")
+	// ErrInvalidPosition is returned for positions outside the longitude and latitude
+	// ranges, NaN included
+	ErrInvalidPosition = errors.New("coordenadas devem estar entre -180 e 180 de longitude e -90 e 90 de latitude")
+	// ErrRingTooShort is returned for rings with fewer than four positions
+	ErrRingTooShort = errors.New("um anel do polígono deve ter ao menos quatro posições")
+	// ErrRingNotClosed is returned for rings whose last position differs from the first
+	ErrRingNotClosed = errors.New("um anel do polígono deve terminar na posição em que começa")
+)
+
+// Position is a GeoJSON position: longitude, then latitude, in degrees. An altitude,
+// when present, is dropped.
+type Position [2]float64
+
+func (p Position) Lon() float64 { return p[0] }
+func (p Position) Lat() float64 { return p[1] }
+
+func (p *Position) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) < 2 {
+		return fmt.Errorf("posição deve ter longitude e latitude: %s", data)
+	}
+	*p = Position{values[0], values[1]}
+	return nil
+}
+
+// Ring is a closed line: its last position repeats the first
+type Ring []Position
+
+// Polygon is an exterior ring followed by the rings of its holes
+type Polygon []Ring
+
+// Geometry is a GeoJSON Polygon or MultiPolygon. A Polygon is held as a single
+// element of Polygons.
+type Geometry struct {
+	Type     string
+	Polygons []Polygon
+}
+
+// NewPolygon returns a Polygon geometry with the given rings
+func NewPolygon(rings ...Ring) *Geometry {
+	return &Geometry{Type: TypePolygon, Polygons: []Polygon{rings}}
+}
+
+// NewMultiPolygon returns a MultiPolygon geometry with the given polygons
+func NewMultiPolygon(polygons ...Polygon) *Geometry {
+	return &Geometry{Type: TypeMultiPolygon, Polygons: polygons}
+}
+
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	var coordinates interface{} = g.Polygons
+	if g.Type == TypePolygon && len(g.Polygons) == 1 {
+		coordinates = g.Polygons[0]
+	}
+	return json.Marshal(struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}{g.Type, coordinates})
+}
+
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch raw.Type {
+	case TypePolygon:
+		var polygon Polygon
+		if err := json.Unmarshal(raw.Coordinates, &polygon); err != nil {
+			return err
+		}
+		*g = Geometry{Type: TypePolygon, Polygons: []Polygon{polygon}}
+	case TypeMultiPolygon:
+		var polygons []Polygon
+		if err := json.Unmarshal(raw.Coordinates, &polygons); err != nil {
+			return err
+		}
+		*g = Geometry{Type: TypeMultiPolygon, Polygons: polygons}
+	default:
+		return ErrUnsupportedType
+	}
+	return nil
+}
+
+// Value stores the geometry as its GeoJSON text
+func (g Geometry) Value() (driver.Value, error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (g *Geometry) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, g)
+	case string:
+		return json.Unmarshal([]byte(v), g)
+	default:
+		return fmt.Errorf("não é possível converter %T em geometria", value)
+	}
+}
+
+// Validate checks that the geometry has at least one polygon and that every ring is
+// closed, has at least four positions and stays within valid coordinates
+func (g Geometry) Validate() error {
+	if g.Type != TypePolygon && g.Type != TypeMultiPolygon {
+		return ErrUnsupportedType
+	}
+	if len(g.Polygons) == 0 {
+		return ErrEmpty
+	}
+
+	for _, polygon := range g.Polygons {
+		if len(polygon) == 0 {
+			return ErrEmpty
+		}
+		for _, ring := range polygon {
+			if err := ring.validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r Ring) validate() error {
+	if len(r) < 4 {
+		return ErrRingTooShort
+	}
+	if r[0] != r[len(r)-1] {
+		return ErrRingNotClosed
+	}
+	for _, p := range r {
+		if !validPosition(p.Lon(), p.Lat()) {
+			return ErrInvalidPosition
+		}
+	}
+	return nil
+}
+
+// validPosition reports whether lon and lat are within their ranges. The ranges are
+// checked inclusively because NaN fails every comparison and must not slip through.
+func validPosition(lon, lat float64) bool {
+	return math.Abs(lon) <= 180 && math.Abs(lat) <= 90
+}
+
+// Area returns the geodesic area of the geometry in square meters, with the holes
+// subtracted from their polygons
+func (g Geometry) Area() float64 {
+	var total float64
+	for _, polygon := range g.Polygons {
+		total += polygon.Area()
+	}
+	return total
+}
+
+// AreaHectares returns the geodesic area of the geometry in hectares
+func (g Geometry) AreaHectares() float64 {
+	return g.Area() / SquareMetersPerHectare
+}
+
+// Area returns the geodesic area of the polygon in square meters
+func (p Polygon) Area() float64 {
+	if len(p) == 0 {
+		return 0
+	}
+
+	area := math.Abs(p[0].signedArea())
+	for _, hole := range p[1:] {
+		area -= math.Abs(hole.signedArea())
+	}
+	return math.Max(area, 0)
+}
+
+// signedArea computes the area enclosed by the ring on a sphere of radius EarthRadius
+// (Chamberlain and Duquette, "Some Algorithms for Polygons on a Sphere", 2007). The
+// sign depends on the winding order.
+func (r Ring) signedArea() float64 {
+	n := len(r) - 1 // the closing position repeats the first
+	if n < 3 {
+		return 0
+	}
+
+	var total float64
+	for i := 0; i < n; i++ {
+		prev := r[(i+n-1)%n]
+		next := r[(i+1)%n]
+		total += (radians(next.Lon()) - radians(prev.Lon())) * math.Sin(radians(r[i].Lat()))
+	}
+	return total * EarthRadius * EarthRadius / 2
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
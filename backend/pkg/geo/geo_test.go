@@ -0,0 +1,562 @@
+// pkg/geo/geo_test.go
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func square(lon, lat, size float64) Ring {
+	return Ring{{lon, lat}, {lon + size, lat}, {lon + size, lat + size}, {lon, lat + size}, {lon, lat}}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Geometry
+		err      error
+	}{
+		{
+			name:     "Polygon",
+			input:    `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+			expected: Geometry{Type: TypePolygon, Polygons: []Polygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}},
+		},
+		{
+			name:     "Altitude Dropped",
+			input:    `{"type":"Polygon","coordinates":[[[0,0,10],[1,0,10],[1,1,10],[0,0,10]]]}`,
+			expected: Geometry{Type: TypePolygon, Polygons: []Polygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}},
+		},
+		{
+			name:  "MultiPolygon",
+			input: `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,2]]]]}`,
+			expected: Geometry{Type: TypeMultiPolygon, Polygons: []Polygon{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				{{{2, 2}, {3, 2}, {3, 3}, {2, 2}}},
+			}},
+		},
+		{
+			name:  "Point",
+			input: `{"type":"Point","coordinates":[0,0]}`,
+			err:   ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g Geometry
+			err := json.Unmarshal([]byte(tt.input), &g)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(g, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, g)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry *Geometry
+		expected string
+	}{
+		{
+			name:     "Polygon",
+			geometry: NewPolygon(Ring{{0, 0}, {1, 0}, {1, 1}, {0, 0}}),
+			expected: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		},
+		{
+			name:     "MultiPolygon",
+			geometry: NewMultiPolygon(Polygon{Ring{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			expected: `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.geometry)
+			if err != nil {
+				t.Fatalf("Marshal returned error: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, data)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry Geometry
+		err      error
+	}{
+		{name: "Valid", geometry: *NewPolygon(square(-55, -12, 0.01))},
+		{name: "Unsupported Type", geometry: Geometry{Type: "LineString"}, err: ErrUnsupportedType},
+		{name: "No Polygons", geometry: Geometry{Type: TypeMultiPolygon}, err: ErrEmpty},
+		{name: "Ring Too Short", geometry: *NewPolygon(Ring{{0, 0}, {1, 1}, {0, 0}}), err: ErrRingTooShort},
+		{name: "Ring Not Closed", geometry: *NewPolygon(Ring{{0, 0}, {1, 0}, {1, 1}, {0, 1}}), err: ErrRingNotClosed},
+		{name: "Latitude Out Of Range", geometry: *NewPolygon(square(0, 89.5, 1)), err: ErrInvalidPosition},
+		{
+			name:     "Longitude Not A Number",
+			geometry: *NewPolygon(Ring{{0, 0}, {math.NaN(), 0}, {1, 1}, {0, 1}, {0, 0}}),
+			err:      ErrInvalidPosition,
+		},
+		{
+			name:     "Infinite Latitude",
+			geometry: *NewPolygon(Ring{{0, 0}, {1, 0}, {1, math.Inf(1)}, {0, 1}, {0, 0}}),
+			err:      ErrInvalidPosition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.geometry.Validate(); !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestArea(t *testing.T) {
+	// A cell between two meridians and two parallels has an exact area on the sphere
+	cell := func(lat1, lat2, dLon float64) float64 {
+		return EarthRadius * EarthRadius * radians(dLon) * (math.Sin(radians(lat2)) - math.Sin(radians(lat1)))
+	}
+
+	tests := []struct {
+		name     string
+		geometry *Geometry
+		expected float64
+	}{
+		{
+			name:     "One Degree At The Equator",
+			geometry: NewPolygon(square(0, 0, 1)),
+			expected: cell(0, 1, 1),
+		},
+		{
+			name:     "Clockwise Ring",
+			geometry: NewPolygon(Ring{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}),
+			expected: cell(0, 1, 1),
+		},
+		{
+			name:     "Farm In Mato Grosso",
+			geometry: NewPolygon(square(-55.8, -12.6, 0.01)),
+			expected: cell(-12.6, -12.59, 0.01),
+		},
+		{
+			name:     "Polygon With Hole",
+			geometry: NewPolygon(square(0, 0, 2), square(0.5, 0.5, 1)),
+			expected: cell(0, 2, 2) - cell(0.5, 1.5, 1),
+		},
+		{
+			name:     "MultiPolygon",
+			geometry: NewMultiPolygon(Polygon{square(0, 0, 1)}, Polygon{square(10, 0, 1)}),
+			expected: 2 * cell(0, 1, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.geometry.Area()
+			if math.Abs(got-tt.expected) > tt.expected*1e-9 {
+				t.Errorf("Area() = %f, want %f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAreaHectares(t *testing.T) {
+	// About 1.1 km by 1.1 km near Sorriso, MT
+	got := NewPolygon(square(-55.7, -12.55, 0.01)).AreaHectares()
+	if got < 118 || got > 124 {
+		t.Errorf("AreaHectares() = %f, want about 121", got)
+	}
+}
+
+func TestScan(t *testing.T) {
+	original := NewPolygon(square(-55, -12, 0.01))
+	value, err := original.Value()
+	if err != nil {
+		t.Fatalf("Value returned error: %v", err)
+	}
+
+	var scanned Geometry
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if !reflect.DeepEqual(&scanned, original) {
+		t.Errorf("Expected %+v, got %+v", original, scanned)
+	}
+}
+
+func TestParseBBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected BBox
+		err      error
+	}{
+		{
+			name:     "Valid",
+			input:    "-56.1, -13.0,-55.5,-12.2",
+			expected: BBox{MinLon: -56.1, MinLat: -13.0, MaxLon: -55.5, MaxLat: -12.2},
+		},
+		{name: "Missing Coordinate", input: "-56.1,-13.0,-55.5", err: ErrInvalidBBox},
+		{name: "Not A Number", input: "a,-13.0,-55.5,-12.2", err: ErrInvalidBBox},
+		{name: "Min After Max", input: "-55.5,-13.0,-56.1,-12.2", err: ErrInvalidBBox},
+		{name: "Out Of Range", input: "-190,-13.0,-55.5,-12.2", err: ErrInvalidBBox},
+		{name: "Coordinate Not A Number", input: "-56.1,NaN,-55.5,-12.2", err: ErrInvalidBBox},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box, err := ParseBBox(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if tt.err == nil && box != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, box)
+			}
+		})
+	}
+}
+
+func TestBoundsAndIntersects(t *testing.T) {
+	g := NewMultiPolygon(Polygon{square(0, 0, 1)}, Polygon{square(3, 2, 1)})
+
+	bounds := g.Bounds()
+	expected := BBox{MinLon: 0, MinLat: 0, MaxLon: 4, MaxLat: 3}
+	if bounds != expected {
+		t.Fatalf("Expected bounds %+v, got %+v", expected, bounds)
+	}
+
+	if !bounds.Intersects(BBox{MinLon: 3.5, MinLat: 2.5, MaxLon: 10, MaxLat: 10}) {
+		t.Errorf("Expected overlapping boxes to intersect")
+	}
+	if !bounds.Intersects(BBox{MinLon: 4, MinLat: 3, MaxLon: 5, MaxLat: 5}) {
+		t.Errorf("Expected boxes sharing a corner to intersect")
+	}
+	if bounds.Intersects(BBox{MinLon: 5, MinLat: 0, MaxLon: 6, MaxLat: 1}) {
+		t.Errorf("Expected disjoint boxes not to intersect")
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry *Geometry
+		expected Point
+	}{
+		{
+			name:     "Square",
+			geometry: NewPolygon(square(-56, -13, 2)),
+			expected: Point{-55, -12},
+		},
+		{
+			name:     "Clockwise Square",
+			geometry: NewPolygon(Ring{{0, 0}, {0, 2}, {2, 2}, {2, 0}, {0, 0}}),
+			expected: Point{1, 1},
+		},
+		{
+			// a 4x4 square with a 2x2 hole in its left half: the remaining mass is
+			// pulled to the right
+			name:     "Hole",
+			geometry: NewPolygon(square(0, 0, 4), Ring{{0.5, 1}, {0.5, 3}, {2.5, 3}, {2.5, 1}, {0.5, 1}}),
+			expected: Point{13.0 / 6, 2},
+		},
+		{
+			name:     "MultiPolygon",
+			geometry: NewMultiPolygon(Polygon{square(0, 0, 1)}, Polygon{square(4, 0, 1)}),
+			expected: Point{2.5, 0.5},
+		},
+		{
+			name:     "Degenerate",
+			geometry: NewPolygon(Ring{{0, 0}, {2, 0}, {1, 0}, {0, 0}}),
+			expected: Point{0.75, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.geometry.Centroid()
+			if math.Abs(c[0]-tt.expected[0]) > 1e-9 || math.Abs(c[1]-tt.expected[1]) > 1e-9 {
+				t.Errorf("Expected centroid %v, got %v", tt.expected, c)
+			}
+		})
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	// a square of about 1.1 km whose bottom edge has a vertex 1 m off the line
+	ring := Ring{{0, 0}, {0.005, 0.00001}, {0.01, 0}, {0.01, 0.01}, {0, 0.01}, {0, 0}}
+	g := NewPolygon(ring)
+
+	simplified := g.Simplify(10)
+	expected := Ring{{0, 0}, {0.01, 0}, {0.01, 0.01}, {0, 0.01}, {0, 0}}
+	if !reflect.DeepEqual(simplified.Polygons[0][0], expected) {
+		t.Errorf("Expected %v, got %v", expected, simplified.Polygons[0][0])
+	}
+	if len(g.Polygons[0][0]) != len(ring) {
+		t.Errorf("Simplify modified the original geometry")
+	}
+
+	if kept := g.Simplify(0.1); !reflect.DeepEqual(kept.Polygons[0][0], ring) {
+		t.Errorf("Expected the vertex beyond the tolerance to be kept, got %v", kept.Polygons[0][0])
+	}
+
+	// a tolerance larger than the square would collapse it
+	if collapsed := g.Simplify(100000); !reflect.DeepEqual(collapsed.Polygons[0][0], ring) {
+		t.Errorf("Expected a collapsing ring to be kept, got %v", collapsed.Polygons[0][0])
+	}
+	if err := g.Simplify(10).Validate(); err != nil {
+		t.Errorf("Expected a valid simplified geometry, got %v", err)
+	}
+}
+
+func TestFeatureCollectionMarshalJSON(t *testing.T) {
+	collection := FeatureCollection{Features: []Feature{
+		{ID: 1, Geometry: Point{-55.7, -12.5}, Properties: map[string]string{"farmName": "Boa Vista"}},
+	}}
+
+	data, err := json.Marshal(collection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"type":"FeatureCollection","features":[{"type":"Feature","id":1,` +
+		`"geometry":{"type":"Point","coordinates":[-55.7,-12.5]},"properties":{"farmName":"Boa Vista"}}]}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	data, err = json.Marshal(FeatureCollection{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("Expected an empty features array, got %s", data)
+	}
+}
+
+func TestFromPolygons(t *testing.T) {
+	if _, err := FromPolygons(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	g, err := FromPolygons([]Polygon{{square(0, 0, 1)}})
+	if err != nil || !reflect.DeepEqual(g, NewPolygon(square(0, 0, 1))) {
+		t.Errorf("Expected a Polygon, got %+v (%v)", g, err)
+	}
+
+	g, err = FromPolygons([]Polygon{{square(0, 0, 1)}, {square(2, 0, 1)}})
+	if err != nil || g.Type != TypeMultiPolygon || len(g.Polygons) != 2 {
+		t.Errorf("Expected a MultiPolygon of two polygons, got %+v (%v)", g, err)
+	}
+}
+
+func TestRingOrientation(t *testing.T) {
+	ring := square(0, 0, 1)
+	if ring.Clockwise() {
+		t.Errorf("Expected %v to wind counterclockwise", ring)
+	}
+
+	reversed := ring.Reversed()
+	if !reversed.Clockwise() {
+		t.Errorf("Expected %v to wind clockwise", reversed)
+	}
+	if ring[1] != (Position{1, 0}) {
+		t.Errorf("Reversed modified the original ring")
+	}
+}
+
+func TestRingClosed(t *testing.T) {
+	open := Ring{{0, 0}, {1, 0}, {1, 1}}
+	if closed := open.Closed(); !reflect.DeepEqual(closed, Ring{{0, 0}, {1, 0}, {1, 1}, {0, 0}}) {
+		t.Errorf("Expected the ring closed, got %v", closed)
+	}
+
+	ring := square(0, 0, 1)
+	if closed := ring.Closed(); !reflect.DeepEqual(closed, ring) {
+		t.Errorf("Expected a closed ring unchanged, got %v", closed)
+	}
+}
+
+func TestRingContains(t *testing.T) {
+	// an L shaped ring: the square of side 2 without its top right quarter
+	ring := Ring{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}, {0, 0}}
+
+	tests := []struct {
+		position Position
+		expected bool
+	}{
+		{Position{0.5, 0.5}, true},
+		{Position{1.5, 0.5}, true},
+		{Position{0.5, 1.5}, true},
+		{Position{1.5, 1.5}, false},
+		{Position{3, 0.5}, false},
+		{Position{-1, 1}, false},
+	}
+
+	for _, tt := range tests {
+		if got := ring.Contains(tt.position); got != tt.expected {
+			t.Errorf("Contains(%v) = %v, want %v", tt.position, got, tt.expected)
+		}
+	}
+}
+
+func TestIntersectionArea(t *testing.T) {
+	// the square of side 2 without its top right quarter, and its mirror image
+	lShape := Ring{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}, {0, 0}}
+	mirrored := Ring{{2, 0}, {2, 2}, {1, 2}, {1, 1}, {0, 1}, {0, 0}, {2, 0}}
+
+	tests := []struct {
+		name     string
+		a, b     *Geometry
+		expected *Geometry
+	}{
+		{
+			name:     "Partial Overlap",
+			a:        NewPolygon(square(0, 0, 2)),
+			b:        NewPolygon(square(1, 1, 2)),
+			expected: NewPolygon(square(1, 1, 1)),
+		},
+		{
+			name:     "Neighbouring Farms",
+			a:        NewPolygon(square(-55.7, -12.55, 0.01)),
+			b:        NewPolygon(square(-55.695, -12.545, 0.01)),
+			expected: NewPolygon(square(-55.695, -12.545, 0.005)),
+		},
+		{
+			name:     "Identical",
+			a:        NewPolygon(lShape),
+			b:        NewPolygon(lShape.Reversed()),
+			expected: NewPolygon(lShape),
+		},
+		{
+			name:     "Contained",
+			a:        NewPolygon(square(0, 0, 4)),
+			b:        NewPolygon(square(1, 1, 1)),
+			expected: NewPolygon(square(1, 1, 1)),
+		},
+		{
+			name:     "Concave And Convex",
+			a:        NewPolygon(lShape),
+			b:        NewPolygon(square(0.5, 0.5, 2)),
+			expected: NewPolygon(Ring{{0.5, 0.5}, {2, 0.5}, {2, 1}, {1, 1}, {1, 2}, {0.5, 2}, {0.5, 0.5}}),
+		},
+		{
+			name:     "Both Concave",
+			a:        NewPolygon(lShape),
+			b:        NewPolygon(mirrored),
+			expected: NewPolygon(Ring{{0, 0}, {2, 0}, {2, 1}, {0, 1}, {0, 0}}),
+		},
+		{
+			name:     "Over A Hole",
+			a:        NewPolygon(square(0, 0, 2), square(0.5, 0.5, 1)),
+			b:        NewPolygon(square(0, 0, 1)),
+			expected: NewPolygon(square(0, 0, 1), square(0.5, 0.5, 0.5)),
+		},
+		{
+			name:     "MultiPolygon",
+			a:        NewMultiPolygon(Polygon{square(0, 0, 1)}, Polygon{square(2, 0, 1)}),
+			b:        NewPolygon(square(0.5, 0, 2)),
+			expected: NewMultiPolygon(Polygon{Ring{{0.5, 0}, {1, 0}, {1, 1}, {0.5, 1}, {0.5, 0}}}, Polygon{Ring{{2, 0}, {2.5, 0}, {2.5, 1}, {2, 1}, {2, 0}}}),
+		},
+		{
+			name:     "Sharing An Edge",
+			a:        NewPolygon(square(0, 0, 1)),
+			b:        NewPolygon(square(1, 0, 1)),
+			expected: nil,
+		},
+		{
+			name:     "Apart",
+			a:        NewPolygon(square(0, 0, 1)),
+			b:        NewPolygon(square(5, 5, 1)),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var expected float64
+			if tt.expected != nil {
+				expected = tt.expected.Area()
+			}
+
+			for _, got := range []float64{IntersectionArea(*tt.a, *tt.b), IntersectionArea(*tt.b, *tt.a)} {
+				if math.Abs(got-expected) > math.Max(expected*1e-9, 1e-6) {
+					t.Errorf("IntersectionArea() = %f, want %f", got, expected)
+				}
+			}
+		})
+	}
+
+	// holes on both sides are only taken away once where they overlap
+	a := NewPolygon(square(0, 0, 4), square(1, 1, 2))
+	b := NewPolygon(square(0, 0, 4), square(2, 2, 2))
+	expected := NewPolygon(square(0, 0, 4)).Area() - NewPolygon(square(1, 1, 2)).Area() -
+		NewPolygon(square(2, 2, 2)).Area() + NewPolygon(square(2, 2, 1)).Area()
+	if got := IntersectionArea(*a, *b); math.Abs(got-expected) > expected*1e-9 {
+		t.Errorf("IntersectionArea() with holes = %f, want %f", got, expected)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Position
+		expected float64
+	}{
+		{name: "Same Position", a: Position{-55.7, -12.55}, b: Position{-55.7, -12.55}, expected: 0},
+		{name: "One Degree Along The Equator", a: Position{0, 0}, b: Position{1, 0}, expected: MeanEarthRadius * math.Pi / 180},
+		{name: "Pole To Pole", a: Position{0, -90}, b: Position{120, 90}, expected: MeanEarthRadius * math.Pi},
+		// Sorriso to Sinop, MT: about 80 km along the BR-163
+		{name: "Sorriso To Sinop", a: Position{-55.711, -12.545}, b: Position{-55.503, -11.864}, expected: 78700},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Distance(tt.a, tt.b)
+			if math.Abs(got-tt.expected) > math.Max(tt.expected*0.01, 1e-6) {
+				t.Errorf("Distance() = %f, want %f", got, tt.expected)
+			}
+			if back := Distance(tt.b, tt.a); math.Abs(back-got) > 1e-6 {
+				t.Errorf("Distance() is not symmetric: %f and %f", got, back)
+			}
+		})
+	}
+}
+
+func TestAround(t *testing.T) {
+	center := Position{-55.7, -12.55}
+	box := Around(center, 30000)
+
+	// every position 30 km away lies in the box, and the box is barely larger than that
+	for bearing := 0.0; bearing < 360; bearing += 5 {
+		angle := 30000 / MeanEarthRadius
+		lat := math.Asin(math.Sin(radians(center.Lat()))*math.Cos(angle) +
+			math.Cos(radians(center.Lat()))*math.Sin(angle)*math.Cos(radians(bearing)))
+		lon := radians(center.Lon()) + math.Atan2(math.Sin(radians(bearing))*math.Sin(angle)*math.Cos(radians(center.Lat())),
+			math.Cos(angle)-math.Sin(radians(center.Lat()))*math.Sin(lat))
+		p := Position{lon * 180 / math.Pi, lat * 180 / math.Pi}
+
+		if p.Lon() < box.MinLon-1e-9 || p.Lon() > box.MaxLon+1e-9 || p.Lat() < box.MinLat-1e-9 || p.Lat() > box.MaxLat+1e-9 {
+			t.Errorf("Position %v at bearing %v is outside %+v", p, bearing, box)
+		}
+	}
+	if width := box.MaxLon - box.MinLon; width > 0.6 {
+		t.Errorf("Expected a box about 0.55 degrees wide, got %f", width)
+	}
+
+	if polar := Around(Position{10, -89}, 200000); polar.MinLat != -90 || polar.MinLon != -180 || polar.MaxLon != 180 {
+		t.Errorf("Expected a circle around the pole to span every longitude, got %+v", polar)
+	}
+	if wrapped := Around(Position{179.9, 0}, 30000); wrapped.MinLon != -180 || wrapped.MaxLon != 180 {
+		t.Errorf("Expected a circle across the antimeridian to span every longitude, got %+v", wrapped)
+	}
+}
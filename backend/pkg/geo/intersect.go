@@ -0,0 +1,207 @@
+// pkg/geo/intersect.go
+package geo
+
+import "math"
+
+// point is a position projected on the Lambert cylindrical equal-area projection of
+// the unit sphere: x is the longitude in radians and y the sine of the latitude. Areas
+// in that plane are the areas signedArea measures on the sphere.
+type point struct {
+	x, y float64
+}
+
+// IntersectionArea returns the geodesic area, in square meters, the two geometries
+// have in common. The polygons of each geometry are assumed not to overlap each other.
+func IntersectionArea(a, b Geometry) float64 {
+	if !a.Bounds().Intersects(b.Bounds()) {
+		return 0
+	}
+
+	var total float64
+	for _, pa := range a.Polygons {
+		for _, pb := range b.Polygons {
+			total += pa.intersectionArea(pb)
+		}
+	}
+	return total * EarthRadius * EarthRadius
+}
+
+// intersectionArea returns the area shared by two polygons on the unit sphere. Holes
+// lie inside their exterior ring and apart from each other, so by inclusion-exclusion
+// each pair of rings adds its common area when both are exteriors or both are holes
+// and takes it away otherwise.
+func (p Polygon) intersectionArea(q Polygon) float64 {
+	var total float64
+	for i, ra := range p {
+		for j, rb := range q {
+			shared := ringIntersectionArea(project(ra), project(rb))
+			if (i == 0) != (j == 0) {
+				shared = -shared
+			}
+			total += shared
+		}
+	}
+	return math.Max(total, 0)
+}
+
+// project converts the ring to the equal-area plane, dropping the closing position and
+// repeated positions
+func project(r Ring) []point {
+	points := make([]point, 0, len(r))
+	for i, p := range r {
+		if i == len(r)-1 && len(r) > 1 && p == r[0] {
+			break
+		}
+		projected := point{radians(p.Lon()), math.Sin(radians(p.Lat()))}
+		if len(points) > 0 && points[len(points)-1] == projected {
+			continue
+		}
+		points = append(points, projected)
+	}
+	return points
+}
+
+// ringIntersectionArea returns the area shared by the regions two rings enclose. The
+// smaller ring is cut into triangles and the other one is clipped by each of them.
+func ringIntersectionArea(a, b []point) float64 {
+	if len(a) < 3 || len(b) < 3 || !overlaps(boundsOf(a), boundsOf(b)) {
+		return 0
+	}
+	if len(b) > len(a) {
+		a, b = b, a
+	}
+
+	boxA := boundsOf(a)
+	var total float64
+	for _, triangle := range triangulate(b) {
+		if !overlaps(boxA, boundsOf(triangle[:])) {
+			continue
+		}
+		total += math.Abs(planarArea(clip(a, triangle)))
+	}
+	return total
+}
+
+// triangulate cuts a simple ring into triangles by ear clipping. The triangles wind
+// counterclockwise.
+func triangulate(ring []point) [][3]point {
+	vertices := make([]point, len(ring))
+	copy(vertices, ring)
+	if planarArea(vertices) < 0 {
+		for i, j := 0, len(vertices)-1; i < j; i, j = i+1, j-1 {
+			vertices[i], vertices[j] = vertices[j], vertices[i]
+		}
+	}
+
+	triangles := make([][3]point, 0, len(vertices)-2)
+	for i, misses := 0, 0; len(vertices) > 3; {
+		n := len(vertices)
+		prev, curr, next := vertices[(i+n-1)%n], vertices[i%n], vertices[(i+1)%n]
+
+		turn := cross(prev, curr, next)
+		// Collinear vertices add no area and are dropped. After a whole lap without
+		// an ear, which only a self-intersecting ring causes, the vertex is cut anyway
+		// so the loop ends.
+		if turn == 0 || (turn > 0 && isEar(vertices, i%n)) || misses >= n {
+			if turn > 0 || misses >= n {
+				triangles = append(triangles, [3]point{prev, curr, next})
+			}
+			vertices = append(vertices[:i%n], vertices[i%n+1:]...)
+			misses = 0
+			continue
+		}
+
+		i = (i + 1) % n
+		misses++
+	}
+	if len(vertices) == 3 {
+		triangles = append(triangles, [3]point{vertices[0], vertices[1], vertices[2]})
+	}
+	return triangles
+}
+
+// isEar reports whether no other vertex lies inside the triangle the vertex at i forms
+// with its neighbours. Only reflex vertices can, so the others are skipped.
+func isEar(vertices []point, i int) bool {
+	n := len(vertices)
+	a, b, c := vertices[(i+n-1)%n], vertices[i], vertices[(i+1)%n]
+
+	for j := range vertices {
+		if j == i || j == (i+n-1)%n || j == (i+1)%n {
+			continue
+		}
+		p := vertices[j]
+		if cross(vertices[(j+n-1)%n], p, vertices[(j+1)%n]) > 0 {
+			continue
+		}
+		if cross(a, b, p) >= 0 && cross(b, c, p) >= 0 && cross(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// clip returns the part of subject inside a counterclockwise triangle
+// (Sutherland-Hodgman). The subject need not be convex: its pieces inside the
+// triangle come out joined by edges that enclose no area.
+func clip(subject []point, triangle [3]point) []point {
+	output := subject
+	for k := 0; k < 3 && len(output) > 0; k++ {
+		a, b := triangle[k], triangle[(k+1)%3]
+		input := output
+		output = make([]point, 0, len(input)+2)
+
+		for i, curr := range input {
+			prev := input[(i+len(input)-1)%len(input)]
+			currInside := cross(a, b, curr) >= 0
+			prevInside := cross(a, b, prev) >= 0
+
+			if currInside != prevInside {
+				output = append(output, intersection(prev, curr, a, b))
+			}
+			if currInside {
+				output = append(output, curr)
+			}
+		}
+	}
+	return output
+}
+
+// intersection returns where the segment p-q crosses the line through a and b
+func intersection(p, q, a, b point) point {
+	dp, dq := cross(a, b, p), cross(a, b, q)
+	t := dp / (dp - dq)
+	return point{p.x + t*(q.x-p.x), p.y + t*(q.y-p.y)}
+}
+
+// cross is positive when a, b and c turn counterclockwise
+func cross(a, b, c point) float64 {
+	return (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+}
+
+// planarArea is the signed area of an open ring, positive when counterclockwise
+func planarArea(ring []point) float64 {
+	var total float64
+	for i, p := range ring {
+		q := ring[(i+1)%len(ring)]
+		total += p.x*q.y - q.x*p.y
+	}
+	return total / 2
+}
+
+type box struct {
+	minX, minY, maxX, maxY float64
+}
+
+func boundsOf(points []point) box {
+	b := box{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, p := range points {
+		b.minX, b.minY = math.Min(b.minX, p.x), math.Min(b.minY, p.y)
+		b.maxX, b.maxY = math.Max(b.maxX, p.x), math.Max(b.maxY, p.y)
+	}
+	return b
+}
+
+func overlaps(a, b box) bool {
+	return a.minX <= b.maxX && b.minX <= a.maxX && a.minY <= b.maxY && b.minY <= a.maxY
+}
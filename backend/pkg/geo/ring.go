@@ -0,0 +1,56 @@
+// pkg/geo/ring.go
+package geo
+
+// FromPolygons returns a Polygon geometry for a single polygon and a MultiPolygon for
+// several, or ErrEmpty when there is none
+func FromPolygons(polygons []Polygon) (*Geometry, error) {
+	switch len(polygons) {
+	case 0:
+		return nil, ErrEmpty
+	case 1:
+		return NewPolygon(polygons[0]...), nil
+	default:
+		return NewMultiPolygon(polygons...), nil
+	}
+}
+
+// Closed returns the ring with its first position repeated at the end when it is not
+// already there
+func (r Ring) Closed() Ring {
+	if len(r) == 0 || r[0] == r[len(r)-1] {
+		return r
+	}
+	closed := make(Ring, len(r), len(r)+1)
+	copy(closed, r)
+	return append(closed, r[0])
+}
+
+// Clockwise reports whether the ring winds clockwise with longitude to the east and
+// latitude to the north
+func (r Ring) Clockwise() bool {
+	area, _, _ := r.planarCentroid()
+	return area < 0
+}
+
+// Reversed returns a copy of the ring with the opposite winding order
+func (r Ring) Reversed() Ring {
+	reversed := make(Ring, len(r))
+	for i, p := range r {
+		reversed[len(r)-1-i] = p
+	}
+	return reversed
+}
+
+// Contains reports whether p lies inside the ring (even-odd rule). Positions on the
+// edge may fall either way.
+func (r Ring) Contains(p Position) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Lat() > p.Lat()) != (b.Lat() > p.Lat()) &&
+			p.Lon() < (b.Lon()-a.Lon())*(p.Lat()-a.Lat())/(b.Lat()-a.Lat())+a.Lon() {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,155 @@
+// pkg/geo/simplify.go
+package geo
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// metersPerDegree is the length of a degree of latitude, or of longitude at the equator
+const metersPerDegree = EarthRadius * math.Pi / 180
+
+// Point is a GeoJSON Point geometry
+type Point Position
+
+func (p Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type        string   `json:"type"`
+		Coordinates Position `json:"coordinates"`
+	}{"Point", Position(p)})
+}
+
+// Centroid returns the center of mass of the geometry, with the holes taken out. The
+// polygons are treated as planar, which is precise enough at the size of a farm.
+func (g Geometry) Centroid() Point {
+	var area, lon, lat float64
+	for _, polygon := range g.Polygons {
+		for i, ring := range polygon {
+			a, cLon, cLat := ring.planarCentroid()
+			// the exterior ring adds to the mass and the holes take from it, whatever
+			// their winding order
+			if (i == 0) != (a > 0) {
+				a = -a
+			}
+			area += a
+			lon += a * cLon
+			lat += a * cLat
+		}
+	}
+
+	if area == 0 {
+		return g.vertexMean()
+	}
+	return Point{lon / area, lat / area}
+}
+
+// planarCentroid returns the signed planar area of the ring and its centroid
+func (r Ring) planarCentroid() (area, lon, lat float64) {
+	for i := 0; i+1 < len(r); i++ {
+		cross := r[i].Lon()*r[i+1].Lat() - r[i+1].Lon()*r[i].Lat()
+		area += cross
+		lon += (r[i].Lon() + r[i+1].Lon()) * cross
+		lat += (r[i].Lat() + r[i+1].Lat()) * cross
+	}
+	if area == 0 {
+		return 0, 0, 0
+	}
+	area /= 2
+	return area, lon / (6 * area), lat / (6 * area)
+}
+
+// vertexMean is the centroid of degenerate geometries, whose rings enclose no area
+func (g Geometry) vertexMean() Point {
+	var lon, lat float64
+	var n int
+	for _, polygon := range g.Polygons {
+		if len(polygon) == 0 {
+			continue
+		}
+		for _, p := range polygon[0] {
+			lon += p.Lon()
+			lat += p.Lat()
+			n++
+		}
+	}
+	if n == 0 {
+		return Point{}
+	}
+	return Point{lon / float64(n), lat / float64(n)}
+}
+
+// Simplify returns a copy of the geometry with the vertices closer than tolerance
+// meters to the simplified outline removed (Douglas-Peucker). Rings that would
+// collapse are kept as they are, so the result is still a valid geometry.
+func (g Geometry) Simplify(tolerance float64) *Geometry {
+	simplified := &Geometry{Type: g.Type, Polygons: make([]Polygon, len(g.Polygons))}
+	for i, polygon := range g.Polygons {
+		simplified.Polygons[i] = make(Polygon, len(polygon))
+		for j, ring := range polygon {
+			simplified.Polygons[i][j] = ring.simplify(tolerance)
+		}
+	}
+	return simplified
+}
+
+func (r Ring) simplify(tolerance float64) Ring {
+	if tolerance <= 0 || len(r) <= 4 {
+		return r
+	}
+
+	// Distances are measured in degrees of latitude, with the longitudes shrunk to
+	// the scale of the ring's latitude
+	scale := math.Cos(radians(r[0].Lat()))
+	keep := make([]bool, len(r))
+	keep[0], keep[len(r)-1] = true, true
+	r.douglasPeucker(0, len(r)-1, tolerance/metersPerDegree, scale, keep)
+
+	simplified := make(Ring, 0, len(r))
+	for i, p := range r {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	if len(simplified) < 4 {
+		return r
+	}
+	return simplified
+}
+
+func (r Ring) douglasPeucker(first, last int, tolerance, scale float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+
+	farthest, distance := -1, tolerance
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(r[i], r[first], r[last], scale); d > distance {
+			farthest, distance = i, d
+		}
+	}
+	if farthest < 0 {
+		return
+	}
+
+	keep[farthest] = true
+	r.douglasPeucker(first, farthest, tolerance, scale, keep)
+	r.douglasPeucker(farthest, last, tolerance, scale, keep)
+}
+
+// segmentDistance is the distance from p to the segment between a and b. When the
+// segment is a single point, as with the ends of a closed ring, it is the distance to
+// that point.
+func segmentDistance(p, a, b Position, scale float64) float64 {
+	px, py := p.Lon()*scale, p.Lat()
+	ax, ay := a.Lon()*scale, a.Lat()
+	bx, by := b.Lon()*scale, b.Lat()
+
+	dx, dy := bx-ax, by-ay
+	if dx == 0 && dy == 0 {
+		return math.Hypot(px-ax, py-ay)
+	}
+
+	t := ((px-ax)*dx + (py-ay)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
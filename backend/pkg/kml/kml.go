@@ -0,0 +1,319 @@
+// pkg/kml/kml.go
+package kml
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+// Media types of KML documents and of KMZ archives
+const (
+	ContentType    = "application/vnd.google-earth.kml+xml"
+	KMZContentType = "application/vnd.google-earth.kmz"
+)
+
+// namespace is the OGC KML 2.2 namespace written on exported documents
+const namespace = "http://www.opengis.net/kml/2.2"
+
+// maxDocumentSize caps the uncompressed size of the document read from a KMZ, as a
+// small upload can inflate to gigabytes
+const maxDocumentSize = 64 << 20
+
+var (
+	// ErrNoPolygon is returned for documents without any Polygon
+	ErrNoPolygon = errors.New("o arquivo KML não possui polígonos")
+	// ErrInvalidCoordinates is returned for coordinates that are not lon,lat[,alt] tuples
+	ErrInvalidCoordinates = errors.New("o arquivo KML possui coordenadas inválidas")
+	// ErrNoDocument is returned for KMZ archives without a .kml file
+	ErrNoDocument = errors.New("o arquivo KMZ não contém um documento KML")
+	// ErrDocumentTooLarge is returned for KMZ documents larger than maxDocumentSize
+	// once uncompressed
+	ErrDocumentTooLarge = errors.New("o documento KML do arquivo KMZ deve ter no máximo 64 MB descompactado")
+)
+
+// Placemark is a named geometry with its extended data
+type Placemark struct {
+	Name     string
+	Data     []Data
+	Geometry *geo.Geometry
+}
+
+// Data is a name and value pair of a placemark's ExtendedData
+type Data struct {
+	Name  string
+	Value string
+}
+
+type document struct {
+	XMLName  xml.Name `xml:"kml"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Document struct {
+		Name       string      `xml:"name"`
+		Placemarks []placemark `xml:"Placemark"`
+	} `xml:"Document"`
+}
+
+type placemark struct {
+	Name          string         `xml:"name"`
+	ExtendedData  *extendedData  `xml:"ExtendedData,omitempty"`
+	Polygon       *polygon       `xml:"Polygon,omitempty"`
+	MultiGeometry *multiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+type extendedData struct {
+	Data []data `xml:"Data"`
+}
+
+type data struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type multiGeometry struct {
+	Polygons []polygon `xml:"Polygon"`
+}
+
+type polygon struct {
+	Outer boundary   `xml:"outerBoundaryIs"`
+	Inner []boundary `xml:"innerBoundaryIs"`
+}
+
+type boundary struct {
+	Coordinates string `xml:"LinearRing>coordinates"`
+}
+
+// IsKMZ reports whether data is a zip archive, as KMZ files are
+func IsKMZ(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// Read returns every polygon of a KML document, or of the document inside a KMZ
+// archive, as a single geometry
+func Read(data []byte) (*geo.Geometry, error) {
+	if IsKMZ(data) {
+		doc, err := extractDocument(data)
+		if err != nil {
+			return nil, err
+		}
+		data = doc
+	}
+
+	polygons, err := decodePolygons(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(polygons) == 0 {
+		return nil, ErrNoPolygon
+	}
+	return geo.FromPolygons(polygons)
+}
+
+// extractDocument returns the root document of a KMZ archive: doc.kml when present,
+// otherwise the first .kml file. Its size is checked against the header first, and
+// the read is limited too, in case the header lies.
+func extractDocument(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("arquivo KMZ inválido: %w", err)
+	}
+
+	var found *zip.File
+	for _, file := range archive.File {
+		if !strings.EqualFold(path.Ext(file.Name), ".kml") {
+			continue
+		}
+		if found == nil || strings.EqualFold(path.Base(file.Name), "doc.kml") {
+			found = file
+		}
+	}
+	if found == nil {
+		return nil, ErrNoDocument
+	}
+	if found.UncompressedSize64 > maxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	rc, err := found.Open()
+	if err != nil {
+		return nil, fmt.Errorf("arquivo KMZ inválido: %w", err)
+	}
+	defer rc.Close()
+
+	document, err := io.ReadAll(io.LimitReader(rc, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("arquivo KMZ inválido: %w", err)
+	}
+	if len(document) > maxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+	return document, nil
+}
+
+// decodePolygons collects the Polygon elements wherever they are nested: in
+// Placemarks, MultiGeometries or Folders
+func decodePolygons(r io.Reader) ([]geo.Polygon, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		encoding, err := ianaindex.IANA.Encoding(label)
+		if err != nil || encoding == nil {
+			return nil, fmt.Errorf("codificação %q não suportada", label)
+		}
+		return encoding.NewDecoder().Reader(input), nil
+	}
+
+	var polygons []geo.Polygon
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return polygons, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("arquivo KML inválido: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Polygon" {
+			continue
+		}
+
+		var p polygon
+		if err := decoder.DecodeElement(&p, &start); err != nil {
+			return nil, fmt.Errorf("arquivo KML inválido: %w", err)
+		}
+		decoded, err := p.decode()
+		if err != nil {
+			return nil, err
+		}
+		polygons = append(polygons, decoded)
+	}
+}
+
+func (p polygon) decode() (geo.Polygon, error) {
+	outer, err := parseCoordinates(p.Outer.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+
+	rings := geo.Polygon{outer}
+	for _, inner := range p.Inner {
+		ring, err := parseCoordinates(inner.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+	}
+	return rings, nil
+}
+
+// parseCoordinates reads a whitespace separated list of lon,lat[,alt] tuples. Rings
+// left open are closed.
+func parseCoordinates(text string) (geo.Ring, error) {
+	var ring geo.Ring
+	for _, tuple := range strings.Fields(text) {
+		values := strings.Split(tuple, ",")
+		if len(values) < 2 {
+			return nil, ErrInvalidCoordinates
+		}
+
+		lon, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return nil, ErrInvalidCoordinates
+		}
+		lat, err := strconv.ParseFloat(values[1], 64)
+		if err != nil {
+			return nil, ErrInvalidCoordinates
+		}
+		ring = append(ring, geo.Position{lon, lat})
+	}
+	return ring.Closed(), nil
+}
+
+// Write encodes the placemarks as a KML document called name
+func Write(w io.Writer, name string, placemarks []Placemark) error {
+	doc := document{Xmlns: namespace}
+	doc.Document.Name = name
+	for _, pm := range placemarks {
+		doc.Document.Placemarks = append(doc.Document.Placemarks, encodePlacemark(pm))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteKMZ encodes the placemarks as a KMZ archive holding a single doc.kml
+func WriteKMZ(w io.Writer, name string, placemarks []Placemark) error {
+	archive := zip.NewWriter(w)
+	doc, err := archive.Create("doc.kml")
+	if err != nil {
+		return err
+	}
+	if err := Write(doc, name, placemarks); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func encodePlacemark(pm Placemark) placemark {
+	encoded := placemark{Name: pm.Name}
+
+	if len(pm.Data) > 0 {
+		encoded.ExtendedData = &extendedData{}
+		for _, d := range pm.Data {
+			encoded.ExtendedData.Data = append(encoded.ExtendedData.Data, data{Name: d.Name, Value: d.Value})
+		}
+	}
+
+	if pm.Geometry == nil {
+		return encoded
+	}
+	if len(pm.Geometry.Polygons) == 1 {
+		p := encodePolygon(pm.Geometry.Polygons[0])
+		encoded.Polygon = &p
+		return encoded
+	}
+
+	encoded.MultiGeometry = &multiGeometry{}
+	for _, rings := range pm.Geometry.Polygons {
+		encoded.MultiGeometry.Polygons = append(encoded.MultiGeometry.Polygons, encodePolygon(rings))
+	}
+	return encoded
+}
+
+func encodePolygon(rings geo.Polygon) polygon {
+	var p polygon
+	for i, ring := range rings {
+		b := boundary{Coordinates: formatCoordinates(ring)}
+		if i == 0 {
+			p.Outer = b
+		} else {
+			p.Inner = append(p.Inner, b)
+		}
+	}
+	return p
+}
+
+func formatCoordinates(ring geo.Ring) string {
+	tuples := make([]string, len(ring))
+	for i, p := range ring {
+		tuples[i] = strconv.FormatFloat(p.Lon(), 'f', -1, 64) + "," + strconv.FormatFloat(p.Lat(), 'f', -1, 64)
+	}
+	return strings.Join(tuples, " ")
+}
@@ -0,0 +1,219 @@
+// pkg/kml/kml_test.go
+package kml
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+const carDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>MT-5107925-AREA_IMOVEL</name>
+        <Polygon>
+          <outerBoundaryIs>
+            <LinearRing>
+              <coordinates>
+                -55.7,-12.55,0 -55.69,-12.55,0 -55.69,-12.54,0 -55.7,-12.54,0 -55.7,-12.55,0
+              </coordinates>
+            </LinearRing>
+          </outerBoundaryIs>
+          <innerBoundaryIs>
+            <LinearRing>
+              <coordinates>-55.698,-12.548 -55.696,-12.548 -55.696,-12.546 -55.698,-12.548</coordinates>
+            </LinearRing>
+          </innerBoundaryIs>
+        </Polygon>
+      </Placemark>
+    </Folder>
+  </Document>
+</kml>`
+
+var carPolygon = geo.Polygon{
+	{{-55.7, -12.55}, {-55.69, -12.55}, {-55.69, -12.54}, {-55.7, -12.54}, {-55.7, -12.55}},
+	{{-55.698, -12.548}, {-55.696, -12.548}, {-55.696, -12.546}, {-55.698, -12.548}},
+}
+
+func kmz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		entry, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		entry.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// inflating returns a KMZ whose document's header claims it inflates to size
+func inflating(t *testing.T, size uint64) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	entry, err := archive.CreateRaw(&zip.FileHeader{Name: "doc.kml", Method: zip.Deflate, CompressedSize64: 1, UncompressedSize64: size})
+	if err != nil {
+		t.Fatalf("Failed to create doc.kml: %v", err)
+	}
+	entry.Write([]byte{0})
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected *geo.Geometry
+		err      error
+	}{
+		{
+			name:     "KML",
+			input:    []byte(carDocument),
+			expected: geo.NewPolygon(carPolygon...),
+		},
+		{
+			name:     "KMZ",
+			input:    kmz(t, map[string]string{"files/logo.png": "png", "doc.kml": carDocument}),
+			expected: geo.NewPolygon(carPolygon...),
+		},
+		{
+			name: "MultiGeometry",
+			input: []byte(`<kml><Placemark><MultiGeometry>
+				<Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs></Polygon>
+				<Polygon><outerBoundaryIs><LinearRing><coordinates>2,2 3,2 3,3 2,2</coordinates></LinearRing></outerBoundaryIs></Polygon>
+			</MultiGeometry></Placemark></kml>`),
+			expected: geo.NewMultiPolygon(
+				geo.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				geo.Polygon{{{2, 2}, {3, 2}, {3, 3}, {2, 2}}},
+			),
+		},
+		{
+			name:     "Open Ring Closed",
+			input:    []byte(`<kml><Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1</coordinates></LinearRing></outerBoundaryIs></Polygon></kml>`),
+			expected: geo.NewPolygon(geo.Ring{{0, 0}, {1, 0}, {1, 1}, {0, 0}}),
+		},
+		{
+			name: "Latin-1 Encoding",
+			input: append([]byte(`<?xml version="1.0" encoding="ISO-8859-1"?><kml><Placemark><name>Fazenda S`),
+				append([]byte{0xE3}, []byte(`o Jos&#233;</name><Polygon><outerBoundaryIs><LinearRing><coordinates>0,0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs></Polygon></Placemark></kml>`)...)...),
+			expected: geo.NewPolygon(geo.Ring{{0, 0}, {1, 0}, {1, 1}, {0, 0}}),
+		},
+		{
+			name:  "No Polygon",
+			input: []byte(`<kml><Placemark><Point><coordinates>0,0</coordinates></Point></Placemark></kml>`),
+			err:   ErrNoPolygon,
+		},
+		{
+			name:  "Invalid Coordinates",
+			input: []byte(`<kml><Polygon><outerBoundaryIs><LinearRing><coordinates>0;0 1,0 1,1 0,0</coordinates></LinearRing></outerBoundaryIs></Polygon></kml>`),
+			err:   ErrInvalidCoordinates,
+		},
+		{
+			name:  "KMZ Without Document",
+			input: kmz(t, map[string]string{"area.shp": "shp"}),
+			err:   ErrNoDocument,
+		},
+		{
+			name:  "KMZ Document Inflates Too Much",
+			input: inflating(t, maxDocumentSize+1),
+			err:   ErrDocumentTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Read(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(g, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, g)
+			}
+		})
+	}
+}
+
+func TestRead_MalformedXML(t *testing.T) {
+	if _, err := Read([]byte(`<kml><Polygon>`)); err == nil {
+		t.Errorf("Expected an error for a truncated document")
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	placemarks := []Placemark{
+		{
+			Name:     "Fazenda São José",
+			Data:     []Data{{Name: "state", Value: "MT"}, {Name: "owner", Value: "João & Filhos"}},
+			Geometry: geo.NewPolygon(carPolygon...),
+		},
+		{
+			Name: "Fazenda Dividida",
+			Geometry: geo.NewMultiPolygon(
+				geo.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				geo.Polygon{{{2, 2}, {3, 2}, {3, 3}, {2, 2}}},
+			),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "fazendas", placemarks); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	doc := buf.String()
+	for _, expected := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<kml xmlns="http://www.opengis.net/kml/2.2">`,
+		`<name>Fazenda São José</name>`,
+		`<Data name="owner">`,
+		`<value>João &amp; Filhos</value>`,
+		`<coordinates>-55.7,-12.55 -55.69,-12.55 -55.69,-12.54 -55.7,-12.54 -55.7,-12.55</coordinates>`,
+		`<MultiGeometry>`,
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("Expected the document to contain %s:\n%s", expected, doc)
+		}
+	}
+
+	g, err := Read(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to read the written document: %v", err)
+	}
+	expected := geo.NewMultiPolygon(append([]geo.Polygon{carPolygon}, placemarks[1].Geometry.Polygons...)...)
+	if !reflect.DeepEqual(g, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, g)
+	}
+
+	buf.Reset()
+	if err := WriteKMZ(&buf, "fazendas", placemarks[:1]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !IsKMZ(buf.Bytes()) {
+		t.Fatalf("Expected a zip archive")
+	}
+	g, err = Read(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to read the written archive: %v", err)
+	}
+	if !reflect.DeepEqual(g, geo.NewPolygon(carPolygon...)) {
+		t.Errorf("Unexpected geometry read from the archive: %+v", g)
+	}
+}
@@ -0,0 +1,73 @@
+// pkg/mergepatch/mergepatch.go
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ContentType is the media type of RFC 7396 merge patch documents
+const ContentType = "application/merge-patch+json"
+
+// Apply applies an RFC 7396 JSON merge patch to the original document: objects are
+// merged recursively, null removes a member and any other value, arrays included,
+// replaces the target value.
+func Apply(original, patch []byte) ([]byte, error) {
+	var target interface{}
+	if len(bytes.TrimSpace(original)) > 0 {
+		if err := decode(original, &target); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes interface{}
+	if err := decode(patch, &changes); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(target, changes))
+}
+
+// Fields returns the top-level members named by a merge patch object, including the
+// ones it removes with null
+func Fields(patch []byte) (map[string]bool, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]bool, len(members))
+	for name := range members {
+		fields[name] = true
+	}
+	return fields, nil
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = merge(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// decode keeps numbers as json.Number so that values untouched by the patch are
+// written back exactly as they were
+func decode(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
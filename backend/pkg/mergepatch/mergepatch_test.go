@@ -0,0 +1,71 @@
+// pkg/mergepatch/mergepatch_test.go
+package mergepatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Cases from RFC 7396, Appendix A, plus the ones this API relies on
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+	}{
+		{"Replace Member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Add Member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"Remove Member", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"Remove One Of Two", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"Replace Array", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Array Replaces Value", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"Nested Merge", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"Arrays Are Not Merged", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"Non Object Patch", `{"a":"foo"}`, `["c"]`, `["c"]`},
+		{"Null Patch", `{"a":"foo"}`, `null`, `null`},
+		{"Empty Original", ``, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"Numbers Kept Exactly", `{"totalArea":1234.5600,"city":"X"}`, `{"city":"Y"}`, `{"totalArea":1234.5600,"city":"Y"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.original), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply returned error: %v", err)
+			}
+
+			var gotValue, expectedValue interface{}
+			if err := json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("Apply returned invalid JSON %s: %v", got, err)
+			}
+			if err := json.Unmarshal([]byte(tt.expected), &expectedValue); err != nil {
+				t.Fatalf("Invalid expected JSON: %v", err)
+			}
+			if !reflect.DeepEqual(gotValue, expectedValue) {
+				t.Errorf("Apply = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApply_InvalidPatch(t *testing.T) {
+	if _, err := Apply([]byte(`{"a":1}`), []byte(`{"a":`)); err == nil {
+		t.Error("Apply should fail on invalid patch")
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields, err := Fields([]byte(`{"farmName":"Nova","city":null}`))
+	if err != nil {
+		t.Fatalf("Fields returned error: %v", err)
+	}
+	if !fields["farmName"] || !fields["city"] || fields["state"] {
+		t.Errorf("Fields = %v, want farmName and city", fields)
+	}
+
+	if _, err := Fields([]byte(`["a"]`)); err == nil {
+		t.Error("Fields should fail when the patch is not an object")
+	}
+}
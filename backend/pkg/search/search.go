@@ -0,0 +1,103 @@
+// pkg/search/search.go
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold lowercases s and strips its accents, the same way unaccent(lower(...)) does on
+// the database side, so "São José" and "sao jose" compare equal
+func Fold(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(foldRune(r))
+	}
+	return b.String()
+}
+
+func foldRune(r rune) string {
+	var b strings.Builder
+	for _, c := range norm.NFD.String(string(r)) {
+		if unicode.Is(unicode.Mn, c) {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
+
+// Terms splits a query into folded words, dropping punctuation
+func Terms(query string) []string {
+	return strings.FieldsFunc(Fold(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// PrefixQuery builds a to_tsquery expression matching documents that contain every
+// term, each one as a word prefix
+func PrefixQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// Highlight HTML-escapes text and wraps every word starting with one of terms in
+// <mark> tags. Matching ignores case and accents, while the original text is kept.
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	folded := make([]string, len(runes))
+	for i, r := range runes {
+		folded[i] = foldRune(r)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		word := string(runes[i:end])
+		if n := matchLength(folded[i:end], terms); n > 0 {
+			b.WriteString("<mark>" + html.EscapeString(string(runes[i:i+n])) + "</mark>")
+			b.WriteString(html.EscapeString(string(runes[i+n : end])))
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// matchLength returns how many runes of the folded word are covered by the longest
+// term it starts with, or zero when none matches
+func matchLength(word []string, terms []string) int {
+	best := 0
+	for _, term := range terms {
+		rest := term
+		n := 0
+		for n < len(word) && rest != "" && strings.HasPrefix(rest, word[n]) && word[n] != "" {
+			rest = rest[len(word[n]):]
+			n++
+		}
+		if rest == "" && n > best {
+			best = n
+		}
+	}
+	return best
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
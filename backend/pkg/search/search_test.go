@@ -0,0 +1,94 @@
+// pkg/search/search_test.go
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := map[string]string{
+		"São José":     "sao jose",
+		"JOÃO":         "joao",
+		"Açaí":         "acai",
+		"already ok 1": "already ok 1",
+	}
+
+	for input, expected := range tests {
+		if got := Fold(input); got != expected {
+			t.Errorf("Fold(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "  Sao   José ", expected: []string{"sao", "jose"}},
+		{query: "529.982-247", expected: []string{"529", "982", "247"}},
+		{query: "'; drop table &|!", expected: []string{"drop", "table"}},
+		{query: "---", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := Terms(tt.query); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Terms(%q) = %q, want %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestPrefixQuery(t *testing.T) {
+	if got := PrefixQuery([]string{"sao", "jose"}); got != "sao:* & jose:*" {
+		t.Errorf("PrefixQuery returned %q", got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected string
+	}{
+		{
+			name:     "Accent Insensitive",
+			text:     "Fazenda São José",
+			terms:    []string{"sao", "jose"},
+			expected: "Fazenda <mark>São</mark> <mark>José</mark>",
+		},
+		{
+			name:     "Word Prefix",
+			text:     "João da Silva",
+			terms:    []string{"jo"},
+			expected: "<mark>Jo</mark>ão da Silva",
+		},
+		{
+			name:     "Longest Term Wins",
+			text:     "Joaquim",
+			terms:    []string{"jo", "joaq"},
+			expected: "<mark>Joaq</mark>uim",
+		},
+		{
+			name:     "Only Word Starts",
+			text:     "Mangueira",
+			terms:    []string{"gue"},
+			expected: "Mangueira",
+		},
+		{
+			name:     "Escapes HTML",
+			text:     "<b>Ana</b> & Cia",
+			terms:    []string{"ana"},
+			expected: "&lt;b&gt;<mark>Ana</mark>&lt;/b&gt; &amp; Cia",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Highlight(tt.text, tt.terms); got != tt.expected {
+				t.Errorf("Highlight(%q) = %q, want %q", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// pkg/shapefile/shapefile.go
+package shapefile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+// ContentType is the media type of the zipped shapefiles read and written here
+const ContentType = "application/zip"
+
+// Shape types holding polygons. The Z and M variants carry extra measures after the
+// points, which are ignored.
+const (
+	shapeNull     = 0
+	shapePolygon  = 5
+	shapePolygonZ = 15
+	shapePolygonM = 25
+)
+
+const (
+	fileCode   = 9994
+	version    = 1000
+	headerSize = 100
+)
+
+// maxFileSize caps the uncompressed size of each file read from an archive, as a
+// small upload can inflate to gigabytes
+const maxFileSize = 64 << 20
+
+var (
+	// ErrNoShapefile is returned for archives without a .shp file
+	ErrNoShapefile = errors.New("o arquivo zip não contém um shapefile (.shp)")
+	// ErrInvalidShapefile is returned for .shp files that cannot be decoded
+	ErrInvalidShapefile = errors.New("shapefile inválido")
+	// ErrUnsupportedShape is returned for shapefiles of points or lines
+	ErrUnsupportedShape = errors.New("o shapefile deve conter polígonos")
+	// ErrProjected is returned for shapefiles in a projected coordinate system
+	ErrProjected = errors.New("o shapefile deve estar em coordenadas geográficas (ex.: SIRGAS 2000, EPSG:4674)")
+	// ErrNoPolygon is returned for shapefiles whose records are all empty
+	ErrNoPolygon = errors.New("o shapefile não possui polígonos")
+	// ErrFileTooLarge is returned for archived files larger than maxFileSize once
+	// uncompressed
+	ErrFileTooLarge = errors.New("os arquivos do zip devem ter no máximo 64 MB descompactados")
+)
+
+// IsArchive reports whether data is a zip archive
+func IsArchive(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// IsShp reports whether data starts with the header of a .shp file
+func IsShp(data []byte) bool {
+	return len(data) >= 4 && binary.BigEndian.Uint32(data) == fileCode
+}
+
+// Read returns every polygon of a zipped shapefile, or of a bare .shp file, as a
+// single geometry. The .prj, when present, must describe geographic coordinates.
+func Read(data []byte) (*geo.Geometry, error) {
+	if IsArchive(data) {
+		shp, prj, err := extract(data)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(strings.TrimSpace(strings.ToUpper(prj)), "PROJCS") {
+			return nil, ErrProjected
+		}
+		data = shp
+	}
+
+	polygons, err := decodeShp(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(polygons) == 0 {
+		return nil, ErrNoPolygon
+	}
+	return geo.FromPolygons(polygons)
+}
+
+// extract returns the first .shp of the archive and the .prj next to it, if any
+func extract(data []byte) (shp []byte, prj string, err error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, "", fmt.Errorf("arquivo zip inválido: %w", err)
+	}
+
+	files := make(map[string]*zip.File)
+	var shpName string
+	for _, file := range archive.File {
+		name := strings.ToLower(file.Name)
+		files[name] = file
+		if shpName == "" && path.Ext(name) == ".shp" && !strings.HasPrefix(path.Base(name), "._") {
+			shpName = name
+		}
+	}
+	if shpName == "" {
+		return nil, "", ErrNoShapefile
+	}
+
+	if shp, err = readFile(files[shpName]); err != nil {
+		return nil, "", err
+	}
+	if file, ok := files[strings.TrimSuffix(shpName, ".shp")+".prj"]; ok {
+		raw, err := readFile(file)
+		if err != nil {
+			return nil, "", err
+		}
+		prj = string(raw)
+	}
+	return shp, prj, nil
+}
+
+// readFile decompresses an archived file. The size in the header is checked first,
+// and the read is limited too, in case the header lies.
+func readFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("arquivo zip inválido: %w", err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("arquivo zip inválido: %w", err)
+	}
+	if len(data) > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	return data, nil
+}
+
+// decodeShp reads the polygon records of a .shp file
+func decodeShp(data []byte) ([]geo.Polygon, error) {
+	if len(data) < headerSize || !IsShp(data) {
+		return nil, ErrInvalidShapefile
+	}
+	switch binary.LittleEndian.Uint32(data[32:]) {
+	case shapeNull, shapePolygon, shapePolygonZ, shapePolygonM:
+	default:
+		return nil, ErrUnsupportedShape
+	}
+
+	var polygons []geo.Polygon
+	for offset := headerSize; offset+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[offset+4:])) * 2
+		start, end := offset+8, offset+8+length
+		if length < 4 || end > len(data) {
+			return nil, ErrInvalidShapefile
+		}
+
+		decoded, err := decodeRecord(data[start:end])
+		if err != nil {
+			return nil, err
+		}
+		polygons = append(polygons, decoded...)
+		offset = end
+	}
+	return polygons, nil
+}
+
+// decodeRecord reads the rings of a polygon record and groups each hole with the
+// exterior ring holding it
+func decodeRecord(record []byte) ([]geo.Polygon, error) {
+	switch binary.LittleEndian.Uint32(record) {
+	case shapeNull:
+		return nil, nil
+	case shapePolygon, shapePolygonZ, shapePolygonM:
+	default:
+		return nil, ErrUnsupportedShape
+	}
+
+	// shape type, bounding box, number of parts and number of points
+	if len(record) < 44 {
+		return nil, ErrInvalidShapefile
+	}
+	numParts := int(binary.LittleEndian.Uint32(record[36:]))
+	numPoints := int(binary.LittleEndian.Uint32(record[40:]))
+	partsAt := 44
+	pointsAt := partsAt + 4*numParts
+	if numParts < 0 || numPoints < 0 || pointsAt+16*numPoints > len(record) {
+		return nil, ErrInvalidShapefile
+	}
+
+	rings := make([]geo.Ring, 0, numParts)
+	for i := 0; i < numParts; i++ {
+		first := int(binary.LittleEndian.Uint32(record[partsAt+4*i:]))
+		last := numPoints
+		if i+1 < numParts {
+			last = int(binary.LittleEndian.Uint32(record[partsAt+4*(i+1):]))
+		}
+		if first < 0 || first > last || last > numPoints {
+			return nil, ErrInvalidShapefile
+		}
+
+		ring := make(geo.Ring, 0, last-first)
+		for j := first; j < last; j++ {
+			at := pointsAt + 16*j
+			ring = append(ring, geo.Position{
+				math.Float64frombits(binary.LittleEndian.Uint64(record[at:])),
+				math.Float64frombits(binary.LittleEndian.Uint64(record[at+8:])),
+			})
+		}
+		rings = append(rings, ring.Closed())
+	}
+
+	return groupRings(rings), nil
+}
+
+// groupRings builds polygons out of the rings of a record. Shapefiles wind exterior
+// rings clockwise and holes counterclockwise; a hole outside every exterior ring is
+// kept as a polygon of its own.
+func groupRings(rings []geo.Ring) []geo.Polygon {
+	var polygons []geo.Polygon
+	var holes []geo.Ring
+	for _, ring := range rings {
+		if ring.Clockwise() {
+			polygons = append(polygons, geo.Polygon{ring})
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	for _, hole := range holes {
+		placed := false
+		for i, polygon := range polygons {
+			if len(hole) > 0 && polygon[0].Contains(hole[0]) {
+				polygons[i] = append(polygons[i], hole)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			polygons = append(polygons, geo.Polygon{hole})
+		}
+	}
+	return polygons
+}
@@ -0,0 +1,227 @@
+// pkg/shapefile/shapefile_test.go
+package shapefile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+// exterior is clockwise and hole counterclockwise, as shapefiles store them
+var (
+	exterior = geo.Ring{{-55.7, -12.55}, {-55.7, -12.54}, {-55.69, -12.54}, {-55.69, -12.55}, {-55.7, -12.55}}
+	hole     = geo.Ring{{-55.698, -12.548}, {-55.696, -12.548}, {-55.696, -12.546}, {-55.698, -12.548}}
+	island   = geo.Ring{{-55.6, -12.5}, {-55.6, -12.49}, {-55.59, -12.49}, {-55.6, -12.5}}
+)
+
+var testFields = []Field{
+	{Name: "ID", Type: Numeric, Length: 10},
+	{Name: "NAME", Type: Character, Length: 10},
+	{Name: "AREA_HECTARES", Type: Numeric, Length: 12, Decimals: 2},
+}
+
+func write(t *testing.T, records []Record) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "fazendas", testFields, records); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func unzip(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		files[file.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	return files
+}
+
+func rezip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, data := range files {
+		entry, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		entry.Write(data)
+	}
+	archive.Close()
+	return buf.Bytes()
+}
+
+// inflating returns an archive holding a file whose header claims it inflates to size
+func inflating(t *testing.T, name string, size uint64) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	entry, err := archive.CreateRaw(&zip.FileHeader{Name: name, Method: zip.Deflate, CompressedSize64: 1, UncompressedSize64: size})
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", name, err)
+	}
+	entry.Write([]byte{0})
+	archive.Close()
+	return buf.Bytes()
+}
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	area := 120.96
+	data := write(t, []Record{
+		{Geometry: geo.NewPolygon(exterior, hole), Values: []interface{}{uint(1), "Fazenda São José", area}},
+		{Geometry: nil, Values: []interface{}{uint(2), "Sem Limite", nil}},
+		{Geometry: geo.NewPolygon(island), Values: []interface{}{uint(3), "Ilha", 1.5}},
+	})
+
+	g, err := Read(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := geo.NewMultiPolygon(geo.Polygon{exterior, hole}, geo.Polygon{island})
+	if !reflect.DeepEqual(g, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, g)
+	}
+
+	files := unzip(t, data)
+	for _, name := range []string{"fazendas.shp", "fazendas.shx", "fazendas.dbf", "fazendas.prj", "fazendas.cpg"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Expected %s in the archive", name)
+		}
+	}
+	if !strings.HasPrefix(string(files["fazendas.prj"]), "GEOGCS") {
+		t.Errorf("Expected a geographic .prj, got %s", files["fazendas.prj"])
+	}
+
+	shp, shx := files["fazendas.shp"], files["fazendas.shx"]
+	if got := int(binary.BigEndian.Uint32(shp[24:])) * 2; got != len(shp) {
+		t.Errorf("Header of .shp declares %d bytes, file has %d", got, len(shp))
+	}
+	if got := int(binary.BigEndian.Uint32(shx[24:])) * 2; got != len(shx) || len(shx) != headerSize+3*8 {
+		t.Errorf("Unexpected .shx size: header %d, file %d", got, len(shx))
+	}
+	// every index entry points at a record header with the same content length
+	for i := 0; i < 3; i++ {
+		offset := int(binary.BigEndian.Uint32(shx[headerSize+8*i:])) * 2
+		length := binary.BigEndian.Uint32(shx[headerSize+8*i+4:])
+		if number := binary.BigEndian.Uint32(shp[offset:]); number != uint32(i+1) {
+			t.Errorf("Index entry %d points at record %d", i, number)
+		}
+		if got := binary.BigEndian.Uint32(shp[offset+4:]); got != length {
+			t.Errorf("Index entry %d has length %d, record has %d", i, length, got)
+		}
+	}
+
+	dbf := files["fazendas.dbf"]
+	if records := binary.LittleEndian.Uint32(dbf[4:]); records != 3 {
+		t.Errorf("Expected 3 records in the table, got %d", records)
+	}
+	headerLength := int(binary.LittleEndian.Uint16(dbf[8:]))
+	recordLength := int(binary.LittleEndian.Uint16(dbf[10:]))
+	if headerLength != 32+32*3+1 || recordLength != 1+10+10+12 {
+		t.Fatalf("Unexpected table layout: header %d, record %d", headerLength, recordLength)
+	}
+	if name := string(bytes.TrimRight(dbf[32:43], "\x00")); name != "ID" {
+		t.Errorf("Unexpected first field name %q", name)
+	}
+	if name := string(bytes.TrimRight(dbf[32+64:32+64+11], "\x00")); name != "AREA_HECTA" {
+		t.Errorf("Expected long field names to be cut to ten characters, got %q", name)
+	}
+
+	first := string(dbf[headerLength : headerLength+recordLength])
+	// "Fazenda São José" is cut to ten bytes without splitting the "ã"
+	expectedRecord := " " + "         1" + "Fazenda S " + "      120.96"
+	if first != expectedRecord {
+		t.Errorf("Expected record %q, got %q", expectedRecord, first)
+	}
+	second := string(dbf[headerLength+recordLength : headerLength+2*recordLength])
+	if !strings.HasSuffix(second, strings.Repeat(" ", 12)) {
+		t.Errorf("Expected an empty area in the second record, got %q", second)
+	}
+	if dbf[len(dbf)-1] != 0x1A {
+		t.Errorf("Expected the table to end with the EOF marker")
+	}
+}
+
+func TestWrite_ExteriorWoundClockwise(t *testing.T) {
+	data := write(t, []Record{{Geometry: geo.NewPolygon(exterior.Reversed(), hole.Reversed())}})
+
+	g, err := Read(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(g, geo.NewPolygon(exterior, hole)) {
+		t.Errorf("Expected the rings rewound, got %+v", g)
+	}
+}
+
+func TestWrite_ValueTooLong(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "fazendas", testFields, []Record{{Values: []interface{}{uint(1), "x", 1e15}}})
+	if err == nil {
+		t.Errorf("Expected an error for a number wider than its field")
+	}
+}
+
+func TestRead(t *testing.T) {
+	files := unzip(t, write(t, []Record{{Geometry: geo.NewPolygon(exterior)}}))
+	shp := files["fazendas.shp"]
+
+	projected := map[string][]byte{
+		"CAR/AREA_IMOVEL.shp": shp,
+		"CAR/AREA_IMOVEL.prj": []byte(`PROJCS["SIRGAS_2000_UTM_Zone_21S",GEOGCS["GCS_SIRGAS_2000"]]`),
+	}
+	withoutShp := map[string][]byte{"AREA_IMOVEL.dbf": files["fazendas.dbf"]}
+
+	points := append([]byte(nil), shp...)
+	binary.LittleEndian.PutUint32(points[32:], 1)
+
+	tests := []struct {
+		name     string
+		input    []byte
+		expected *geo.Geometry
+		err      error
+	}{
+		{name: "Bare Shp", input: shp, expected: geo.NewPolygon(exterior)},
+		{name: "Nested In Folder", input: rezip(t, map[string][]byte{"CAR/AREA_IMOVEL.shp": shp}), expected: geo.NewPolygon(exterior)},
+		{name: "Projected", input: rezip(t, projected), err: ErrProjected},
+		{name: "No Shapefile", input: rezip(t, withoutShp), err: ErrNoShapefile},
+		{name: "Inflates Too Much", input: inflating(t, "AREA_IMOVEL.shp", maxFileSize+1), err: ErrFileTooLarge},
+		{name: "Points", input: points, err: ErrUnsupportedShape},
+		{name: "Truncated", input: shp[:len(shp)-10], err: ErrInvalidShapefile},
+		{name: "Header Only", input: shp[:headerSize], err: ErrNoPolygon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Read(tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(g, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, g)
+			}
+		})
+	}
+}
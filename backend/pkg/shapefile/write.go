@@ -0,0 +1,231 @@
+// pkg/shapefile/write.go
+package shapefile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/geo"
+)
+
+// Attribute types of the .dbf table
+const (
+	Character byte = 'C'
+	Numeric   byte = 'N'
+)
+
+// wgs84 is the .prj written with every shapefile: geographic coordinates in degrees
+const wgs84 = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],` +
+	`PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+
+// Field is a column of the attribute table. Names longer than ten characters are cut.
+type Field struct {
+	Name     string
+	Type     byte
+	Length   int
+	Decimals int
+}
+
+// Record is a shape with its attributes, one value per field: a string for Character
+// fields and a number for Numeric ones. A nil value leaves the attribute empty and a
+// nil Geometry writes a null shape.
+type Record struct {
+	Geometry *geo.Geometry
+	Values   []interface{}
+}
+
+// Write encodes the records as a zip archive holding name.shp, .shx, .dbf, .prj and a
+// .cpg declaring the attributes as UTF-8
+func Write(w io.Writer, name string, fields []Field, records []Record) error {
+	shp, shx := encodeShapes(records)
+	dbf, err := encodeTable(fields, records)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range []struct {
+		ext  string
+		data []byte
+	}{
+		{".shp", shp},
+		{".shx", shx},
+		{".dbf", dbf},
+		{".prj", []byte(wgs84)},
+		{".cpg", []byte("UTF-8")},
+	} {
+		entry, err := archive.Create(name + file.ext)
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(file.data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// encodeShapes returns the .shp with a polygon record per record and its .shx index
+func encodeShapes(records []Record) (shp, shx []byte) {
+	var body, index bytes.Buffer
+	bounds := geo.BBox{}
+	hasBounds := false
+
+	for i, record := range records {
+		content := encodePolygon(record.Geometry)
+		if record.Geometry != nil {
+			box := record.Geometry.Bounds()
+			if !hasBounds {
+				bounds, hasBounds = box, true
+			} else {
+				bounds = geo.BBox{
+					MinLon: math.Min(bounds.MinLon, box.MinLon), MinLat: math.Min(bounds.MinLat, box.MinLat),
+					MaxLon: math.Max(bounds.MaxLon, box.MaxLon), MaxLat: math.Max(bounds.MaxLat, box.MaxLat),
+				}
+			}
+		}
+
+		offset := headerSize + body.Len()
+		binary.Write(&index, binary.BigEndian, [2]int32{int32(offset / 2), int32(len(content) / 2)})
+		binary.Write(&body, binary.BigEndian, [2]int32{int32(i + 1), int32(len(content) / 2)})
+		body.Write(content)
+	}
+
+	shp = append(encodeHeader(headerSize+body.Len(), bounds), body.Bytes()...)
+	shx = append(encodeHeader(headerSize+index.Len(), bounds), index.Bytes()...)
+	return shp, shx
+}
+
+func encodeHeader(size int, bounds geo.BBox) []byte {
+	header := make([]byte, headerSize)
+	binary.BigEndian.PutUint32(header[0:], fileCode)
+	binary.BigEndian.PutUint32(header[24:], uint32(size/2))
+	binary.LittleEndian.PutUint32(header[28:], version)
+	binary.LittleEndian.PutUint32(header[32:], shapePolygon)
+	for i, v := range []float64{bounds.MinLon, bounds.MinLat, bounds.MaxLon, bounds.MaxLat} {
+		binary.LittleEndian.PutUint64(header[36+8*i:], math.Float64bits(v))
+	}
+	return header
+}
+
+// encodePolygon writes every ring of the geometry as a part of a single polygon
+// shape, with the exterior rings clockwise and the holes counterclockwise
+func encodePolygon(g *geo.Geometry) []byte {
+	var content bytes.Buffer
+	if g == nil {
+		binary.Write(&content, binary.LittleEndian, int32(shapeNull))
+		return content.Bytes()
+	}
+
+	var parts []int32
+	var points []geo.Position
+	for _, polygon := range g.Polygons {
+		for i, ring := range polygon {
+			if (i == 0) != ring.Clockwise() {
+				ring = ring.Reversed()
+			}
+			parts = append(parts, int32(len(points)))
+			points = append(points, ring...)
+		}
+	}
+
+	box := g.Bounds()
+	binary.Write(&content, binary.LittleEndian, int32(shapePolygon))
+	binary.Write(&content, binary.LittleEndian, [4]float64{box.MinLon, box.MinLat, box.MaxLon, box.MaxLat})
+	binary.Write(&content, binary.LittleEndian, [2]int32{int32(len(parts)), int32(len(points))})
+	binary.Write(&content, binary.LittleEndian, parts)
+	binary.Write(&content, binary.LittleEndian, points)
+	return content.Bytes()
+}
+
+// encodeTable writes the dBASE III table with the attributes of the records
+func encodeTable(fields []Field, records []Record) ([]byte, error) {
+	recordSize := 1
+	for _, field := range fields {
+		recordSize += field.Length
+	}
+	headerLength := 32 + 32*len(fields) + 1
+
+	var table bytes.Buffer
+	now := time.Now()
+	table.Write([]byte{0x03, byte(now.Year() - 1900), byte(now.Month()), byte(now.Day())})
+	binary.Write(&table, binary.LittleEndian, uint32(len(records)))
+	binary.Write(&table, binary.LittleEndian, [2]uint16{uint16(headerLength), uint16(recordSize)})
+	table.Write(make([]byte, 20))
+
+	for _, field := range fields {
+		descriptor := make([]byte, 32)
+		name := field.Name
+		if len(name) > 10 {
+			name = name[:10]
+		}
+		copy(descriptor, name)
+		descriptor[11] = field.Type
+		descriptor[16] = byte(field.Length)
+		descriptor[17] = byte(field.Decimals)
+		table.Write(descriptor)
+	}
+	table.WriteByte(0x0D)
+
+	for i, record := range records {
+		table.WriteByte(' ')
+		for j, field := range fields {
+			var value interface{}
+			if j < len(record.Values) {
+				value = record.Values[j]
+			}
+			encoded, err := encodeValue(field, value)
+			if err != nil {
+				return nil, fmt.Errorf("registro %d: %w", i+1, err)
+			}
+			table.WriteString(encoded)
+		}
+	}
+	table.WriteByte(0x1A)
+
+	return table.Bytes(), nil
+}
+
+// encodeValue formats a value to the field's fixed width: text is cut to fit and
+// padded on the right, numbers are padded on the left
+func encodeValue(field Field, value interface{}) (string, error) {
+	var text string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		text = v
+	case float64:
+		text = strconv.FormatFloat(v, 'f', field.Decimals, 64)
+	case *float64:
+		if v != nil {
+			text = strconv.FormatFloat(*v, 'f', field.Decimals, 64)
+		}
+	case int:
+		text = strconv.Itoa(v)
+	case uint:
+		text = strconv.FormatUint(uint64(v), 10)
+	default:
+		return "", fmt.Errorf("valor %v não suportado no campo %s", value, field.Name)
+	}
+
+	if field.Type == Numeric {
+		if len(text) > field.Length {
+			return "", fmt.Errorf("valor %s não cabe no campo %s", text, field.Name)
+		}
+		return strings.Repeat(" ", field.Length-len(text)) + text, nil
+	}
+
+	for len(text) > field.Length {
+		_, size := utf8.DecodeLastRuneInString(text)
+		text = text[:len(text)-size]
+	}
+	return text + strings.Repeat(" ", field.Length-len(text)), nil
+}
@@ -0,0 +1,99 @@
+// pkg/validation/validation.go
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samuel-prates/farm-project/backend/pkg/apperrors"
+)
+
+// Machine-readable codes attached to each field error
+const (
+	CodeRequired    = "required"
+	CodeInvalid     = "invalid"
+	CodePositive    = "positive"
+	CodeNonNegative = "non_negative"
+	CodeMismatch    = "mismatch"
+	CodeExceeded    = "exceeded"
+)
+
+// FieldError describes a single failing field using its JSON path, e.g. farms[1].totalArea
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors collects every failing field of a validation run
+type Errors []FieldError
+
+// Error implements the error interface
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fe := range e {
+		if fe.Field == "" {
+			messages = append(messages, fe.Message)
+			continue
+		}
+		messages = append(messages, fe.Field+": "+fe.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is makes errors.Is(err, apperrors.ErrValidation) hold for validation errors
+func (e Errors) Is(target error) bool {
+	return target == apperrors.ErrValidation
+}
+
+// Add records a failing field
+func (e *Errors) Add(field, code, message string) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Nest merges the errors returned by a nested Validate call under the given path prefix.
+// Errors that are not validation.Errors are kept as a single invalid entry for the prefix.
+func (e *Errors) Nest(prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	var nested Errors
+	if !errors.As(err, &nested) {
+		e.Add(prefix, CodeInvalid, err.Error())
+		return
+	}
+
+	for _, fe := range nested {
+		fe.Field = Join(prefix, fe.Field)
+		*e = append(*e, fe)
+	}
+}
+
+// Err returns nil when nothing failed, so Validate methods can `return errs.Err()`
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Join builds a dotted field path, skipping empty segments
+func Join(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	case strings.HasPrefix(field, "["):
+		return prefix + field
+	default:
+		return prefix + "." + field
+	}
+}
+
+// Index builds the path of a slice element, e.g. Index("farms", 1) == "farms[1]"
+func Index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
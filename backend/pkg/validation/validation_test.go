@@ -0,0 +1,48 @@
+// pkg/validation/validation_test.go
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors_Nest(t *testing.T) {
+	var harvestErrs Errors
+	harvestErrs.Add("year", CodeRequired, "ano da safra é obrigatório")
+
+	var farmErrs Errors
+	farmErrs.Add("totalArea", CodePositive, "área total deve ser maior que zero")
+	farmErrs.Nest(Index("harvests", 0), harvestErrs.Err())
+
+	var farmerErrs Errors
+	farmerErrs.Nest(Index("farms", 1), farmErrs.Err())
+	farmerErrs.Nest("federalIdentification", errors.New("documento inválido"))
+
+	expected := []FieldError{
+		{Field: "farms[1].totalArea", Code: CodePositive},
+		{Field: "farms[1].harvests[0].year", Code: CodeRequired},
+		{Field: "federalIdentification", Code: CodeInvalid},
+	}
+
+	if len(farmerErrs) != len(expected) {
+		t.Fatalf("Nest produced %d errors, want %d: %v", len(farmerErrs), len(expected), farmerErrs)
+	}
+	for i, fe := range expected {
+		if farmerErrs[i].Field != fe.Field || farmerErrs[i].Code != fe.Code {
+			t.Errorf("Error %d = %+v, want field %q code %q", i, farmerErrs[i], fe.Field, fe.Code)
+		}
+	}
+}
+
+func TestErrors_Err(t *testing.T) {
+	var errs Errors
+	if errs.Err() != nil {
+		t.Errorf("Empty Errors should convert to a nil error")
+	}
+
+	errs.Add("farmerName", CodeRequired, "nome do fazendeiro é obrigatório")
+	var target Errors
+	if !errors.As(errs.Err(), &target) || len(target) != 1 {
+		t.Errorf("Err should expose the collected field errors, got %v", errs.Err())
+	}
+}